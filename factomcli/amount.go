@@ -0,0 +1,177 @@
+package factomcli
+
+import (
+	"fmt"
+
+	"github.com/AdamSLevy/factom"
+	"github.com/posener/complete"
+)
+
+// Approximate sizes in bytes of the parts of a marshaled transaction that
+// are used to estimate its fee. Each input also adds its RCD and signature.
+const (
+	txHeaderSize    = 10
+	txInputSize     = 40
+	txRCDSize       = 33
+	txSignatureSize = 65
+	txOutputSize    = 40
+	txFeeUnitSize   = 1024
+)
+
+// txFee estimates the fee in factoshis of a transaction with the given number
+// of inputs, outputs and EC outputs. Like factomd, it charges 1 EC per KiB of
+// the transaction, 10 EC per output and 1 EC per signature.
+func txFee(rate uint64, inputs, outputs, ecOutputs int) uint64 {
+	size := txHeaderSize +
+		inputs*(txInputSize+txRCDSize+txSignatureSize) +
+		(outputs+ecOutputs)*txOutputSize
+	ecs := (size+txFeeUnitSize-1)/txFeeUnitSize +
+		10*(outputs+ecOutputs) + inputs
+	return uint64(ecs) * rate
+}
+
+// PredictSendAmount predicts the AMOUNT of sendfct as the entire balance of
+// FROMADDRESS minus the fee.
+var PredictSendAmount = PredictArgsFunc(rpcPredictors.sendAmount)
+
+func (p predictors) sendAmount(_ complete.Args, prev []string) []string {
+	if len(prev) < 1 {
+		return nil
+	}
+	rate, ok := p.ecRate()
+	if !ok {
+		return nil
+	}
+	fee := txFee(rate, 1, 1, 0)
+	bal, ok := p.fctBalance(prev[0])
+	if !ok || bal <= fee {
+		return nil
+	}
+	return []string{describe(factom.FactoshiToFactoid(bal-fee),
+		"entire balance minus fee")}
+}
+
+// PredictBuyECAmount predicts the ECAMOUNT of buyec as the number of entry
+// credits that the balance of FCTADDRESS can buy after the fee.
+var PredictBuyECAmount = PredictArgsFunc(rpcPredictors.buyECAmount)
+
+func (p predictors) buyECAmount(_ complete.Args, prev []string) []string {
+	if len(prev) < 1 {
+		return nil
+	}
+	rate, ok := p.ecRate()
+	if !ok {
+		return nil
+	}
+	fee := txFee(rate, 1, 0, 1)
+	bal, ok := p.fctBalance(prev[0])
+	if !ok || bal <= fee {
+		return nil
+	}
+	if ecs := (bal - fee) / rate; ecs > 0 {
+		return []string{describe(fmt.Sprintf("%d", ecs),
+			"entire balance minus fee")}
+	}
+	return nil
+}
+
+// PredictTxInputAmount predicts the AMOUNT of addtxinput as the amount that
+// is still needed to cover the outputs and fee of TXNAME and as the entire
+// balance of ADDRESS.
+var PredictTxInputAmount = PredictArgsFunc(rpcPredictors.txInputAmount)
+
+func (p predictors) txInputAmount(_ complete.Args, prev []string) []string {
+	if len(prev) < 2 {
+		return nil
+	}
+	tx := p.tmpTransaction(prev)
+	if tx == nil {
+		return nil
+	}
+	rate, ok := p.ecRate()
+	if !ok {
+		return nil
+	}
+	bal, ok := p.fctBalance(prev[1])
+	if !ok || bal == 0 {
+		return nil
+	}
+
+	var amounts []string
+	fee := txFee(rate, len(tx.Inputs)+1, len(tx.Outputs), len(tx.ECOutputs))
+	needed := tx.TotalOutputs + tx.TotalECOutputs + fee
+	if needed > tx.TotalInputs && needed-tx.TotalInputs < bal {
+		amounts = append(amounts, describe(
+			factom.FactoshiToFactoid(needed-tx.TotalInputs),
+			"covers outputs and fee"))
+	}
+	amounts = append(amounts, describe(factom.FactoshiToFactoid(bal),
+		"entire balance"))
+	return amounts
+}
+
+// PredictTxOutputAmount predicts the AMOUNT of addtxoutput as the amount of
+// the inputs of TXNAME that is not yet allocated to outputs or the fee.
+var PredictTxOutputAmount = PredictArgsFunc(rpcPredictors.txOutputAmount)
+
+func (p predictors) txOutputAmount(_ complete.Args, prev []string) []string {
+	return p.txRemainingAmount(prev, 1, 0)
+}
+
+// PredictTxECOutputAmount predicts the AMOUNT of addtxecoutput as the amount
+// of the inputs of TXNAME that is not yet allocated to outputs or the fee.
+var PredictTxECOutputAmount = PredictArgsFunc(rpcPredictors.txECOutputAmount)
+
+func (p predictors) txECOutputAmount(_ complete.Args, prev []string) []string {
+	return p.txRemainingAmount(prev, 0, 1)
+}
+
+// txRemainingAmount returns the inputs minus the outputs and the fee of the
+// tmp transaction named by prev after adding the given number of outputs and
+// EC outputs.
+func (p predictors) txRemainingAmount(prev []string,
+	outputs, ecOutputs int) []string {
+	tx := p.tmpTransaction(prev)
+	if tx == nil {
+		return nil
+	}
+	rate, ok := p.ecRate()
+	if !ok {
+		return nil
+	}
+	fee := txFee(rate, len(tx.Inputs), len(tx.Outputs)+outputs,
+		len(tx.ECOutputs)+ecOutputs)
+	allocated := tx.TotalOutputs + tx.TotalECOutputs + fee
+	if allocated >= tx.TotalInputs {
+		return nil
+	}
+	return []string{describe(
+		factom.FactoshiToFactoid(tx.TotalInputs-allocated),
+		"unallocated inputs minus fee")}
+}
+
+// ecRate returns the number of factoshis per entry credit.
+func (p predictors) ecRate() (uint64, bool) {
+	rate, err := p.client.GetRate()
+	if err != nil {
+		complete.Log("error: %v", err)
+		return 0, false
+	}
+	if rate == 0 {
+		return 0, false
+	}
+	return rate, true
+}
+
+// fctBalance returns the balance in factoshis of the FCT address.
+func (p predictors) fctBalance(address string) (uint64, bool) {
+	bal, err := p.client.GetFactoidBalance(address)
+	if err != nil {
+		complete.Log("error: %v", err)
+		return 0, false
+	}
+	if bal < 0 {
+		return 0, false
+	}
+	return uint64(bal), true
+}
@@ -0,0 +1,168 @@
+package factomcli
+
+import (
+	"strings"
+
+	"github.com/posener/complete"
+)
+
+// PositionalArgs returns a Predictor for the positional arguments of a
+// command. The i-th predictor in args predicts the i-th positional argument.
+// The command's flags are used to tell flags and their values apart from
+// positional arguments: a flag whose predictor is complete.PredictNothing is
+// a boolean flag, any other flag consumes a value.
+func PositionalArgs(flags complete.Flags,
+	args ...complete.Predictor) complete.Predictor {
+	return positional{flags: flags, args: args}
+}
+
+type positional struct {
+	flags complete.Flags
+	args  []complete.Predictor
+	// variadic is true if the last predictor also predicts all further
+	// arguments.
+	variadic bool
+}
+
+// ArgsPredictor is implemented by positional argument predictors that depend
+// on the values of the preceding positional arguments.
+type ArgsPredictor interface {
+	PredictArgs(a complete.Args, prev []string) []string
+}
+
+// PredictArgsFunc is an ArgsPredictor that may also be used as a
+// complete.Predictor, in which case prev is nil.
+type PredictArgsFunc func(a complete.Args, prev []string) []string
+
+func (p PredictArgsFunc) Predict(a complete.Args) []string {
+	return p(a, nil)
+}
+
+func (p PredictArgsFunc) PredictArgs(a complete.Args, prev []string) []string {
+	return p(a, prev)
+}
+
+// Predict the positional argument currently being typed.
+func (p positional) Predict(a complete.Args) []string {
+	line := parseLine(p.flags, a.Completed)
+	if len(line.pending) > 0 {
+		// The last completed argument was a combined short flag, such as
+		// -fc, that ends with a flag that consumes a value.
+		if predictor := p.flags[line.pending]; predictor != nil {
+			return predictor.Predict(a)
+		}
+		return nil
+	}
+	i := len(line.args)
+	if i >= len(p.args) && p.variadic {
+		i = len(p.args) - 1
+	}
+	if i >= len(p.args) || p.args[i] == nil {
+		return nil
+	}
+	if predictor, ok := p.args[i].(ArgsPredictor); ok {
+		return predictor.PredictArgs(a, line.args)
+	}
+	return p.args[i].Predict(a)
+}
+
+// flagArg is a flag parsed from the command line. The value is empty for
+// boolean flags.
+type flagArg struct {
+	name  string
+	value string
+}
+
+// cmdLine holds the flags and positional arguments of a command line.
+type cmdLine struct {
+	flags []flagArg
+	args  []string
+	// pending is the name of the flag that still awaits its value.
+	pending string
+	// ended is true if -- ended the flags.
+	ended bool
+}
+
+// parseLine parses the completed arguments of a command. The first element
+// of completed is the command name itself and is skipped. Flags may be given
+// as -flag value, -flag=value or as combined short flags like -fqT. All
+// arguments after -- are positional.
+func parseLine(flags complete.Flags, completed []string) cmdLine {
+	var line cmdLine
+	if len(completed) == 0 {
+		return line
+	}
+	completed = completed[1:]
+	for i := 0; i < len(completed); i++ {
+		arg := completed[i]
+		if arg == "--" {
+			line.args = append(line.args, completed[i+1:]...)
+			line.ended = true
+			break
+		}
+		if len(arg) < 2 || arg[0] != '-' {
+			line.args = append(line.args, arg)
+			continue
+		}
+		var parsed []flagArg
+		parsed, line.pending = parseFlag(flags, arg)
+		if len(line.pending) > 0 && i+1 < len(completed) {
+			i++
+			parsed = append(parsed,
+				flagArg{name: line.pending, value: completed[i]})
+			line.pending = ""
+		}
+		line.flags = append(line.flags, parsed...)
+	}
+	return line
+}
+
+// parseFlag parses a single argument starting with a hyphen. If the last flag
+// in arg consumes a value that is not part of arg, its name is returned as
+// pending.
+func parseFlag(flags complete.Flags, arg string) (parsed []flagArg,
+	pending string) {
+	if i := strings.Index(arg, "="); i > 0 {
+		return []flagArg{{name: arg[:i], value: arg[i+1:]}}, ""
+	}
+	if predictor, ok := flags[arg]; ok || !isShortFlags(flags, arg) {
+		if ok && predictor != nil {
+			return nil, arg
+		}
+		return []flagArg{{name: arg}}, ""
+	}
+	// Combined short flags.
+	for i, c := range arg[1:] {
+		name := "-" + string(c)
+		if flags[name] == nil {
+			parsed = append(parsed, flagArg{name: name})
+			continue
+		}
+		// A flag that consumes a value takes the rest of the argument
+		// or, if there is none, the next argument.
+		if value := arg[2+i:]; len(value) > 0 {
+			return append(parsed, flagArg{name: name, value: value}), ""
+		}
+		return parsed, name
+	}
+	return parsed, ""
+}
+
+// isShortFlags returns true if arg is made up of combined single letter
+// flags that are all in flags, such as -fqT. The letters after a flag that
+// consumes a value are its value, as in -cCHAINID.
+func isShortFlags(flags complete.Flags, arg string) bool {
+	if len(arg) < 3 || arg[1] == '-' {
+		return false
+	}
+	for _, c := range arg[1:] {
+		predictor, ok := flags["-"+string(c)]
+		if !ok {
+			return false
+		}
+		if predictor != nil {
+			return true
+		}
+	}
+	return true
+}
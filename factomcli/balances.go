@@ -0,0 +1,114 @@
+package factomcli
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/AdamSLevy/factom"
+	"github.com/posener/complete"
+)
+
+// balanceTimeout is the deadline for fetching the balances of all addresses.
+// Addresses whose balance is not known by then are listed without one.
+const balanceTimeout = 750 * time.Millisecond
+
+type balance struct {
+	address string
+	amount  int64
+	err     error
+}
+
+// balanceRequests is the number of balances that are requested at once.
+const balanceRequests = 8
+
+// fetchBalances fetches the balances of the FCT and EC addresses from
+// factomd, balanceRequests at a time in the background. FCT balances are in
+// factoshis. Addresses whose balance could not be fetched before
+// balanceTimeout are missing from the returned map. No balances are fetched
+// if the balances predictor is disabled.
+func (p predictors) fetchBalances(fcts, ecs []string) map[string]int64 {
+	parseConnectionFlags()
+	if !predictorEnabled("balances") {
+		return nil
+	}
+
+	type request struct {
+		address string
+		get     func(string) (int64, error)
+	}
+	requests := make(chan request, len(fcts)+len(ecs))
+	for _, fct := range fcts {
+		requests <- request{fct, p.client.GetFactoidBalance}
+	}
+	for _, ec := range ecs {
+		requests <- request{ec, p.client.GetECBalance}
+	}
+	close(requests)
+
+	// The goroutines stop fetching once the deadline passed, and the
+	// channel is large enough that they never block.
+	balances := make(chan balance, len(fcts)+len(ecs))
+	stop := make(chan struct{})
+	defer close(stop)
+	for i := 0; i < balanceRequests && i < len(fcts)+len(ecs); i++ {
+		go func() {
+			for r := range requests {
+				select {
+				case <-stop:
+					return
+				default:
+				}
+				amount, err := r.get(r.address)
+				balances <- balance{r.address, amount, err}
+			}
+		}()
+	}
+
+	amounts := make(map[string]int64)
+	deadline := time.After(balanceTimeout)
+	for i := 0; i < len(fcts)+len(ecs); i++ {
+		select {
+		case b := <-balances:
+			if b.err != nil {
+				complete.Log("error: %v", b.err)
+				continue
+			}
+			amounts[b.address] = b.amount
+		case <-deadline:
+			complete.Log("error: timed out fetching balances")
+			return amounts
+		}
+	}
+	return amounts
+}
+
+// describeBalances annotates each FCT and EC address with its balance if the
+// shell can display descriptions.
+func (p predictors) describeBalances(fcts, ecs []string) ([]string,
+	[]string) {
+	if !showDescriptions() || len(fcts)+len(ecs) == 0 {
+		return fcts, ecs
+	}
+	amounts := p.fetchBalances(fcts, ecs)
+	descs := make(map[string]string)
+	for _, fct := range fcts {
+		if amount, ok := amounts[fct]; ok {
+			descs[fct] = factom.FactoshiToFactoid(uint64(amount)) + " FCT"
+		}
+	}
+	for _, ec := range ecs {
+		if amount, ok := amounts[ec]; ok {
+			descs[ec] = fmt.Sprintf("%d EC", amount)
+		}
+	}
+	return describeAll(fcts, descs), describeAll(ecs, descs)
+}
+
+// describeAll returns the words annotated with their description in descs.
+func describeAll(words []string, descs map[string]string) []string {
+	described := make([]string, len(words))
+	for i, word := range words {
+		described[i] = describe(word, descs[word])
+	}
+	return described
+}
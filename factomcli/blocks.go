@@ -0,0 +1,206 @@
+package factomcli
+
+import (
+	"fmt"
+
+	"github.com/AdamSLevy/factom"
+	"github.com/posener/complete"
+)
+
+// Limits on how far back the chain of blocks is walked. Each block costs one
+// request to factomd, so the blocks are cached.
+const (
+	// maxDBlocks is the number of recent directory blocks suggested.
+	maxDBlocks = 5
+	// maxEBlockChains is the number of chains whose entry blocks are
+	// walked.
+	maxEBlockChains = 3
+	// maxEBlocks is the number of recent entry blocks walked per chain.
+	maxEBlocks = 3
+)
+
+// PredictDBlockKeyMR predicts the KeyMRs of the most recent directory blocks.
+var PredictDBlockKeyMR = complete.PredictFunc(rpcPredictors.dblockKeyMR)
+
+func (p predictors) dblockKeyMR(complete.Args) []string {
+	return p.listDBlockKeyMRs()
+}
+
+// PredictEBlockKeyMR predicts the KeyMRs of the most recent entry blocks of
+// the chains on the command line and in the local chain history.
+var PredictEBlockKeyMR = complete.PredictFunc(rpcPredictors.eblockKeyMR)
+
+func (p predictors) eblockKeyMR(a complete.Args) []string {
+	keymrs, _ := p.listEBlocks(a)
+	return keymrs
+}
+
+// PredictEntryHash predicts the hashes of pending entries and of the entries
+// in the most recent entry blocks of known chains.
+var PredictEntryHash = complete.PredictFunc(rpcPredictors.entryHash)
+
+func (p predictors) entryHash(a complete.Args) []string {
+	_, hashes := p.listEBlocks(a)
+	return append(p.listPendingEntryHashes(), hashes...)
+}
+
+// PredictRawHash predicts any hash accepted by get raw: directory block and
+// entry block KeyMRs and entry hashes.
+var PredictRawHash = complete.PredictFunc(rpcPredictors.rawHash)
+
+func (p predictors) rawHash(a complete.Args) []string {
+	keymrs, hashes := p.listEBlocks(a)
+	hashes = append(p.listPendingEntryHashes(), hashes...)
+	return append(append(p.listDBlockKeyMRs(), keymrs...), hashes...)
+}
+
+type dblockCache struct {
+	KeyMR  string `json:"keymr"`
+	Height int64  `json:"height"`
+}
+
+// eblocksCache holds the recent entry blocks of the chains that were walked.
+type eblocksCache struct {
+	ChainIDs []string      `json:"chainids"`
+	EBlocks  []eblockCache `json:"eblocks"`
+}
+
+type eblockCache struct {
+	ChainID     string   `json:"chainid"`
+	KeyMR       string   `json:"keymr"`
+	Sequence    int64    `json:"sequence"`
+	EntryHashes []string `json:"entryhashes"`
+}
+
+// listDBlockKeyMRs returns the KeyMRs of the cached recent directory blocks.
+func (p predictors) listDBlockKeyMRs() []string {
+	var dblocks []dblockCache
+	if err := p.loadCached(cacheDBlocks, &dblocks); err != nil {
+		complete.Log("error: %v", err)
+		return nil
+	}
+	keymrs := make([]string, len(dblocks))
+	for i, dblock := range dblocks {
+		keymrs[i] = describe(dblock.KeyMR,
+			fmt.Sprintf("directory block %d", dblock.Height))
+	}
+	return keymrs
+}
+
+// fetchDBlocks walks back from the directory block head and returns up to
+// maxDBlocks directory blocks.
+func fetchDBlocks(c Client) (interface{}, error) {
+	keymr, err := c.GetDBlockHead()
+	if err != nil {
+		return nil, err
+	}
+	var dblocks []dblockCache
+	for len(dblocks) < maxDBlocks && keymr != factom.ZeroHash {
+		dblock, err := c.GetDBlock(keymr)
+		if err != nil {
+			complete.Log("error: %v", err)
+			break
+		}
+		dblocks = append(dblocks, dblockCache{keymr,
+			dblock.Header.SequenceNumber})
+		keymr = dblock.Header.PrevBlockKeyMR
+	}
+	return dblocks, nil
+}
+
+// listEBlocks returns the KeyMRs of the cached recent entry blocks of up to
+// maxEBlockChains known chains and the hashes of their entries, newest
+// first. If a chain on the command line is not cached yet, the cache is
+// refreshed in the background.
+func (p predictors) listEBlocks(a complete.Args) (keymrs, hashes []string) {
+	var missing bool
+	defer func() {
+		if missing {
+			startCacheRefresh(cacheEBlocks)
+		}
+	}()
+	chainIDs := chainHistory(a)
+	if len(chainIDs) > maxEBlockChains {
+		chainIDs = chainIDs[:maxEBlockChains]
+	}
+	var eblocks eblocksCache
+	if err := p.loadCached(cacheEBlocks, &eblocks); err != nil {
+		complete.Log("error: %v", err)
+		return nil, nil
+	}
+	byChain := make(map[string][]eblockCache)
+	for _, eblock := range eblocks.EBlocks {
+		byChain[eblock.ChainID] = append(byChain[eblock.ChainID], eblock)
+	}
+	for _, chainID := range chainIDs {
+		if !containsString(eblocks.ChainIDs, chainID) {
+			missing = true
+		}
+		for _, eblock := range byChain[chainID] {
+			keymrs = append(keymrs, describe(eblock.KeyMR, fmt.Sprintf(
+				"entry block %d of chain %s", eblock.Sequence,
+				shortHash(chainID))))
+			for _, hash := range eblock.EntryHashes {
+				hashes = append(hashes, describe(hash,
+					"entry in chain "+shortHash(chainID)))
+			}
+		}
+	}
+	return keymrs, hashes
+}
+
+// fetchEBlocks walks back from the chain heads of up to maxEBlockChains
+// chains of the local chain history and returns up to maxEBlocks entry
+// blocks per chain, with their entries newest first. listEBlocks adds the
+// chains on the command line to the history first.
+func fetchEBlocks(c Client) (interface{}, error) {
+	chainIDs := loadChainHistory()
+	if len(chainIDs) > maxEBlockChains {
+		chainIDs = chainIDs[:maxEBlockChains]
+	}
+	eblocks := eblocksCache{ChainIDs: chainIDs}
+	for _, chainID := range chainIDs {
+		keymr, err := c.GetChainHead(chainID)
+		if err != nil {
+			complete.Log("error: %v", err)
+			continue
+		}
+		for i := 0; i < maxEBlocks && keymr != factom.ZeroHash; i++ {
+			eblock, err := c.GetEBlock(keymr)
+			if err != nil {
+				complete.Log("error: %v", err)
+				break
+			}
+			cached := eblockCache{ChainID: chainID, KeyMR: keymr,
+				Sequence: eblock.Header.BlockSequenceNumber}
+			for j := len(eblock.EntryList) - 1; j >= 0; j-- {
+				cached.EntryHashes = append(cached.EntryHashes,
+					eblock.EntryList[j].EntryHash)
+			}
+			eblocks.EBlocks = append(eblocks.EBlocks, cached)
+			keymr = eblock.Header.PrevKeyMR
+		}
+	}
+	return eblocks, nil
+}
+
+// listPendingEntryHashes returns the hashes of the entries that are pending
+// in factomd.
+func (p predictors) listPendingEntryHashes() []string {
+	var hashes []string
+	for _, e := range p.pendingEntries() {
+		if len(e.EntryHash) > 0 {
+			hashes = append(hashes, describe(e.EntryHash,
+				"pending entry in chain "+shortHash(e.ChainID)))
+		}
+	}
+	return hashes
+}
+
+// shortHash abbreviates a hash for use in a description.
+func shortHash(hash string) string {
+	if len(hash) <= 8 {
+		return hash
+	}
+	return hash[:8] + "…"
+}
@@ -0,0 +1,295 @@
+package factomcli
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/AdamSLevy/factom"
+	"github.com/posener/complete"
+)
+
+// Kinds of data stored in the completion cache.
+const (
+	cacheAddresses      = "addresses"
+	cacheTmpTxs         = "tmptxs"
+	cachePendingEntries = "pendingentries"
+	cacheHeights        = "heights"
+	cacheTxIDs          = "txids"
+	cacheDBlocks        = "dblocks"
+	cacheEBlocks        = "eblocks"
+	// cacheChainNames only names the refresh of the local chain names
+	// file, which is kept outside of the cache.
+	cacheChainNames = "chainnames"
+)
+
+// cacheTTL is how long each kind of cached data is considered fresh. Stale
+// data is still used but triggers a refresh in the background.
+var cacheTTL = map[string]time.Duration{
+	cacheAddresses:      10 * time.Minute,
+	cacheTmpTxs:         10 * time.Second,
+	cachePendingEntries: 1 * time.Minute,
+	cacheHeights:        30 * time.Second,
+	cacheTxIDs:          1 * time.Minute,
+	cacheDBlocks:        1 * time.Minute,
+	cacheEBlocks:        1 * time.Minute,
+}
+
+// cacheServers tells whether each kind of cached data comes from
+// factom-walletd, from factomd or from both.
+var cacheServers = map[string]struct{ wallet, factomd bool }{
+	cacheAddresses:      {wallet: true},
+	cacheTmpTxs:         {wallet: true},
+	cachePendingEntries: {factomd: true},
+	cacheHeights:        {factomd: true},
+	cacheTxIDs:          {wallet: true, factomd: true},
+	cacheDBlocks:        {factomd: true},
+	cacheEBlocks:        {factomd: true},
+	cacheChainNames:     {factomd: true},
+}
+
+// cacheFetch fetches fresh data for each kind of cached data from a Client.
+var cacheFetch = map[string]func(Client) (interface{}, error){
+	cacheAddresses:      fetchAddresses,
+	cacheTmpTxs:         fetchTmpTxs,
+	cachePendingEntries: fetchPendingEntries,
+	cacheHeights:        fetchHeights,
+	cacheTxIDs:          fetchTxIDs,
+	cacheDBlocks:        fetchDBlocks,
+	cacheEBlocks:        fetchEBlocks,
+}
+
+// cacheLockTimeout is how long a refresh lock is honored before it is assumed
+// that the refreshing process died.
+const cacheLockTimeout = 30 * time.Second
+
+// envCacheRefresh is set in the environment of the detached process that
+// refreshes the cache. Its value is the kind of data to refresh.
+const envCacheRefresh = "COMPLETE_FACTOM_CLI_REFRESH"
+
+type cacheEntry struct {
+	Updated time.Time       `json:"updated"`
+	Data    json.RawMessage `json:"data"`
+}
+
+// loadCached unmarshals the cached data of the given kind into v. If nothing
+// is cached, the data is fetched and cached first. If the cached data is
+// older than its TTL it is returned anyway and a detached process is started
+// to refresh it for the next completion. The cache only holds the data of
+// the servers of rpcClient, so other clients are always queried.
+func (p predictors) loadCached(kind string, v interface{}) error {
+	parseConnectionFlags()
+	if !predictorEnabled(kind) {
+		return fmt.Errorf("predictor %s is disabled", kind)
+	}
+	if _, ok := p.client.(rpcClient); !ok {
+		entry, err := fetchCacheEntry(p.client, kind)
+		if err != nil {
+			return err
+		}
+		return json.Unmarshal(entry.Data, v)
+	}
+	entry, err := readCacheEntry(kind)
+	if err != nil {
+		if !os.IsNotExist(err) {
+			complete.Log("error: %v", err)
+		}
+		if entry, err = refreshCacheEntry(kind); err != nil {
+			return err
+		}
+	} else if time.Since(entry.Updated) > cacheTTL[kind] {
+		startCacheRefresh(kind)
+	}
+	return json.Unmarshal(entry.Data, v)
+}
+
+func readCacheEntry(kind string) (cacheEntry, error) {
+	var entry cacheEntry
+	data, err := ioutil.ReadFile(cachePath(kind))
+	if err != nil {
+		return entry, err
+	}
+	err = json.Unmarshal(data, &entry)
+	return entry, err
+}
+
+// fetchCacheEntry fetches fresh data of the given kind from c.
+func fetchCacheEntry(c Client, kind string) (cacheEntry, error) {
+	var entry cacheEntry
+	fetch, ok := cacheFetch[kind]
+	if !ok {
+		return entry, fmt.Errorf("unknown cache kind %q", kind)
+	}
+	v, err := fetch(c)
+	if err != nil {
+		return entry, err
+	}
+	if entry.Data, err = json.Marshal(v); err != nil {
+		return entry, err
+	}
+	entry.Updated = time.Now()
+	return entry, nil
+}
+
+// refreshCacheEntry fetches fresh data of the given kind from the servers of
+// rpcClient and saves it to the cache.
+func refreshCacheEntry(kind string) (cacheEntry, error) {
+	entry, err := fetchCacheEntry(rpcClient{}, kind)
+	if err != nil {
+		return entry, err
+	}
+
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return entry, err
+	}
+	path := cachePath(kind)
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		complete.Log("error: %v", err)
+		return entry, nil
+	}
+	if err := writeFileAtomic(path, data); err != nil {
+		complete.Log("error: %v", err)
+	}
+	return entry, nil
+}
+
+// startCacheRefresh starts a detached copy of this program that refreshes the
+// given kind of cached data. Only one refresh per kind runs at a time.
+func startCacheRefresh(kind string) {
+	// The lock and the flags passed on depend on the connection.
+	parseConnectionFlags()
+	lock := cachePath(kind) + ".lock"
+	if info, err := os.Stat(lock); err == nil &&
+		time.Since(info.ModTime()) < cacheLockTimeout {
+		// A refresh is already running.
+		return
+	}
+	os.Remove(lock)
+	if err := os.MkdirAll(filepath.Dir(lock), 0700); err != nil {
+		complete.Log("error: %v", err)
+		return
+	}
+	f, err := os.OpenFile(lock, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0600)
+	if err != nil {
+		complete.Log("error: %v", err)
+		return
+	}
+	f.Close()
+
+	bin, err := os.Executable()
+	if err != nil {
+		complete.Log("error: %v", err)
+		os.Remove(lock)
+		return
+	}
+	cmd := exec.Command(bin)
+	// Only the connection flags are passed on so that nothing else from
+	// the command line ends up in the environment of another process.
+	var env []string
+	for _, v := range os.Environ() {
+		if !strings.HasPrefix(v, "COMP_LINE=") &&
+			!strings.HasPrefix(v, "COMP_POINT=") {
+			env = append(env, v)
+		}
+	}
+	cmd.Env = append(env, envCacheRefresh+"="+kind,
+		"COMP_LINE=factom-cli "+shellQuoteAll(connArgs))
+	detach(cmd)
+	if err := cmd.Start(); err != nil {
+		complete.Log("error: %v", err)
+		os.Remove(lock)
+		return
+	}
+	cmd.Process.Release()
+}
+
+// refreshCache refreshes the kind of cached data named by envCacheRefresh.
+// It returns false if this process was not started by startCacheRefresh.
+func refreshCache() bool {
+	kind := os.Getenv(envCacheRefresh)
+	if len(kind) == 0 {
+		return false
+	}
+	parseConnectionFlags()
+	defer os.Remove(cachePath(kind) + ".lock")
+	if kind == cacheChainNames {
+		fetchChainNames(rpcClient{}, chainNamesTimeout)
+		return true
+	}
+	if _, err := refreshCacheEntry(kind); err != nil {
+		complete.Log("error: %v", err)
+	}
+	return true
+}
+
+// cachePath returns the path of the cache file for the given kind of data.
+// The cache is kept separately for each server and user of factom-walletd
+// and factomd that the data comes from, so that switching servers or
+// networks never shows the data of another.
+func cachePath(kind string) string {
+	var servers []string
+	if cacheServers[kind].wallet {
+		user, _ := factom.GetWalletRpcConfig()
+		servers = append(servers, "walletd", factom.WalletServer(), user)
+	}
+	if cacheServers[kind].factomd {
+		user, _ := factom.GetFactomdRpcConfig()
+		servers = append(servers, "factomd", factom.FactomdServer(), user)
+	}
+	key := sha256.Sum256([]byte(strings.Join(servers, "\x00")))
+	return filepath.Join(cacheDir(), hex.EncodeToString(key[:8]),
+		kind+".json")
+}
+
+// cacheDir returns the directory that holds the cache of all servers.
+func cacheDir() string {
+	return filepath.Join(dataDir(), "cache")
+}
+
+type addressesCache struct {
+	FCT []string `json:"fct"`
+	EC  []string `json:"ec"`
+}
+
+// fetchAddresses returns the public address strings of all addresses in the
+// wallet. The secret keys are never cached.
+func fetchAddresses(c Client) (interface{}, error) {
+	return walletAddresses(c)
+}
+
+// walletAddresses returns the public FCT and EC addresses in the wallet.
+func walletAddresses(c Client) (addressesCache, error) {
+	fcts, ecs, err := c.FetchAddresses()
+	if err != nil {
+		return addressesCache{}, err
+	}
+
+	// Create slices of the public address strings.
+	addresses := addressesCache{
+		FCT: make([]string, len(fcts)),
+		EC:  make([]string, len(ecs)),
+	}
+	for i, fct := range fcts {
+		addresses.FCT[i] = fct.String()
+	}
+	for i, ec := range ecs {
+		addresses.EC[i] = ec.PubString()
+	}
+	return addresses, nil
+}
+
+func fetchTmpTxs(c Client) (interface{}, error) {
+	return c.ListTransactionsTmp()
+}
+
+func fetchHeights(c Client) (interface{}, error) {
+	return c.GetHeights()
+}
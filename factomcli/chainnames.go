@@ -0,0 +1,265 @@
+package factomcli
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/posener/complete"
+)
+
+// Limits on learning the names of chains. Fetching the first entry of a chain
+// requires walking the entire chain, so it is only done in the background.
+const (
+	// maxChainNameFetches is the number of chains in the local chain
+	// history whose first entry is fetched per refresh.
+	maxChainNameFetches = 3
+	// chainNamesTimeout is the deadline for fetching them all. It is
+	// shorter than cacheLockTimeout so that no second refresh starts
+	// while one is still running.
+	chainNamesTimeout = 20 * time.Second
+)
+
+// PredictChainName predicts the NAME of the N-th -n flag on the line from the
+// N-th name segment of the known chains whose earlier segments match the
+// names given by the preceding -n and -h flags.
+var PredictChainName = complete.PredictFunc(rpcPredictors.chainName)
+
+func (p predictors) chainName(a complete.Args) []string {
+	return p.listChainNames(a, false)
+}
+
+// PredictHexChainName is like PredictChainName but predicts the HEXNAME of a
+// -h flag.
+var PredictHexChainName = complete.PredictFunc(rpcPredictors.hexChainName)
+
+func (p predictors) hexChainName(a complete.Args) []string {
+	return p.listChainNames(a, true)
+}
+
+// listChainNames returns the next name segments of the known chains whose
+// names begin with the names typed on the line, hex encoded if asHex. Names
+// that are not printable are only offered hex encoded.
+func (p predictors) listChainNames(a complete.Args, asHex bool) []string {
+	typed, ok := typedChainName(a.Completed)
+	if !ok {
+		return nil
+	}
+
+	var names []string
+	seen := make(map[string]bool)
+	for _, chain := range p.knownChainNames(a) {
+		if len(chain.name) <= len(typed) || !hasNamePrefix(chain.name, typed) {
+			continue
+		}
+		segment := chain.name[len(typed)]
+		name := hex.EncodeToString(segment)
+		if !asHex {
+			if !isPrintable(segment) {
+				continue
+			}
+			name = string(segment)
+		}
+		if seen[name] {
+			continue
+		}
+		seen[name] = true
+		names = append(names, describe(name, "chain "+shortHash(chain.id)))
+	}
+	return names
+}
+
+// typedChainName returns the name segments given by the -n and -h flags in
+// completed, in order. It returns false if a -h value is not valid hex.
+func typedChainName(completed []string) ([][]byte, bool) {
+	var name [][]byte
+	for i, arg := range completed {
+		var flag, value string
+		switch {
+		case (arg == "-n" || arg == "-h") && i+1 < len(completed):
+			flag, value = arg, completed[i+1]
+		case strings.HasPrefix(arg, "-n=") || strings.HasPrefix(arg, "-h="):
+			flag, value = arg[:2], arg[3:]
+		default:
+			continue
+		}
+		if flag == "-n" {
+			name = append(name, []byte(value))
+			continue
+		}
+		segment, err := hex.DecodeString(value)
+		if err != nil {
+			return nil, false
+		}
+		name = append(name, segment)
+	}
+	return name, true
+}
+
+func hasNamePrefix(name, prefix [][]byte) bool {
+	for i := range prefix {
+		if string(name[i]) != string(prefix[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// isPrintable returns true if the name segment can be typed as text.
+func isPrintable(segment []byte) bool {
+	if len(segment) == 0 || !utf8.Valid(segment) {
+		return false
+	}
+	for _, r := range string(segment) {
+		if !unicode.IsPrint(r) {
+			return false
+		}
+	}
+	return true
+}
+
+type chainName struct {
+	id   string
+	name [][]byte
+}
+
+// knownChainNames returns the names of the chains from chainHistory, most
+// recent first. The names are the ExtIDs of the first entry of each chain,
+// which are remembered in the local chain names file. Chains that are not yet
+// in the file are skipped and their names are fetched in the background.
+func (p predictors) knownChainNames(a complete.Args) []chainName {
+	names := loadChainNames()
+	var missing bool
+	var chains []chainName
+	for _, chainID := range chainHistory(a) {
+		name, ok := names[chainID]
+		if !ok {
+			missing = true
+			continue
+		}
+		chain := chainName{id: chainID}
+		for _, segment := range name {
+			decoded, err := hex.DecodeString(segment)
+			if err != nil {
+				complete.Log("error: %v", err)
+				chain.name = nil
+				break
+			}
+			chain.name = append(chain.name, decoded)
+		}
+		chains = append(chains, chain)
+	}
+	if missing {
+		startCacheRefresh(cacheChainNames)
+	}
+	return chains
+}
+
+// fetchChainNames fetches the names of up to maxChainNameFetches chains of the
+// local chain history that are not yet in the local chain names file and adds
+// them to it. It gives up on the chains not fetched within timeout.
+func fetchChainNames(c Client, timeout time.Duration) {
+	names := loadChainNames()
+	var missing []string
+	for _, chainID := range loadChainHistory() {
+		if _, ok := names[chainID]; !ok &&
+			len(missing) < maxChainNameFetches {
+			missing = append(missing, chainID)
+		}
+	}
+	if len(missing) == 0 {
+		return
+	}
+
+	fetched := make(chan chainName, len(missing))
+	go func() {
+		defer close(fetched)
+		for _, chainID := range missing {
+			entry, err := c.GetFirstEntry(chainID)
+			if err != nil {
+				complete.Log("error: %v", err)
+				continue
+			}
+			fetched <- chainName{id: chainID, name: entry.ExtIDs}
+		}
+	}()
+	deadline := time.After(timeout)
+	changed := false
+	for done := false; !done; {
+		select {
+		case chain, ok := <-fetched:
+			if !ok {
+				done = true
+				break
+			}
+			var name []string
+			for _, extID := range chain.name {
+				name = append(name, hex.EncodeToString(extID))
+			}
+			names[chain.id] = name
+			changed = true
+		case <-deadline:
+			complete.Log("error: chain names: timed out after %v", timeout)
+			done = true
+		}
+	}
+	if changed {
+		saveChainNames(names)
+	}
+}
+
+// loadChainNames reads the local chain names file, which maps chain IDs to
+// their hex encoded name segments.
+func loadChainNames() map[string][]string {
+	names := make(map[string][]string)
+	data, err := ioutil.ReadFile(chainNamesPath())
+	if err != nil {
+		if !os.IsNotExist(err) {
+			complete.Log("error: %v", err)
+		}
+		return names
+	}
+	if err := json.Unmarshal(data, &names); err != nil {
+		complete.Log("error: %v", err)
+		return make(map[string][]string)
+	}
+	return names
+}
+
+// saveChainNames overwrites the local chain names file with the names of the
+// chains that are still in the local chain history.
+func saveChainNames(names map[string][]string) {
+	history := make(map[string]bool)
+	for _, chainID := range loadChainHistory() {
+		history[chainID] = true
+	}
+	for chainID := range names {
+		if !history[chainID] {
+			delete(names, chainID)
+		}
+	}
+
+	path := chainNamesPath()
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		complete.Log("error: %v", err)
+		return
+	}
+	data, err := json.Marshal(names)
+	if err != nil {
+		complete.Log("error: %v", err)
+		return
+	}
+	if err := writeFileAtomic(path, data); err != nil {
+		complete.Log("error: %v", err)
+	}
+}
+
+func chainNamesPath() string {
+	return filepath.Join(dataDir(), "chainnames")
+}
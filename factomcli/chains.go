@@ -0,0 +1,199 @@
+package factomcli
+
+import (
+	"bufio"
+	"encoding/hex"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"os/user"
+	"path/filepath"
+	"strings"
+
+	"github.com/posener/complete"
+)
+
+// maxChainHistory is the maximum number of chain IDs remembered in the local
+// chain history file.
+const maxChainHistory = 100
+
+// PredictChainID predicts the chain IDs on the command line and from the
+// local chain history, most recent first, followed by those of pending
+// entries.
+var PredictChainID = complete.PredictFunc(rpcPredictors.listChainIDs)
+
+// listChainIDs returns the chain IDs of chainHistory followed by those of
+// any pending entries, which are not saved to the history because most of
+// them are chains of others.
+func (p predictors) listChainIDs(a complete.Args) []string {
+	var pending []string
+	for _, e := range p.pendingEntries() {
+		pending = append(pending, e.ChainID)
+	}
+	return mergeChainIDs(chainHistory(a), pending)
+}
+
+// chainHistory returns the chain IDs typed after any -c flag on the command
+// line and the chain IDs from the local chain history, most recent first.
+// Any newly typed chain IDs are saved to the local chain history.
+func chainHistory(a complete.Args) []string {
+	var typed []string
+	for i, arg := range a.Completed {
+		if arg == "-c" && i+1 < len(a.Completed) {
+			typed = append(typed, a.Completed[i+1])
+		}
+	}
+
+	history := loadChainHistory()
+	chainIDs := mergeChainIDs(typed, history)
+	if len(chainIDs) > maxChainHistory {
+		chainIDs = chainIDs[:maxChainHistory]
+	}
+	if !equalStrings(chainIDs, history) {
+		saveChainHistory(chainIDs)
+	}
+	return chainIDs
+}
+
+type pendingEntry struct {
+	EntryHash string `json:"entryhash"`
+	ChainID   string `json:"chainid"`
+}
+
+// pendingEntries returns the entries that are pending in factomd.
+func (p predictors) pendingEntries() []pendingEntry {
+	var entries []pendingEntry
+	if err := p.loadCached(cachePendingEntries, &entries); err != nil {
+		complete.Log("error: %v", err)
+	}
+	return entries
+}
+
+func fetchPendingEntries(c Client) (interface{}, error) {
+	pending, err := c.GetPendingEntries()
+	if err != nil {
+		return nil, err
+	}
+	var entries []pendingEntry
+	if err := json.Unmarshal([]byte(pending), &entries); err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
+
+// mergeChainIDs returns the valid chain IDs from all lists, in order, with
+// duplicates removed.
+func mergeChainIDs(lists ...[]string) []string {
+	var chainIDs []string
+	seen := make(map[string]bool)
+	for _, list := range lists {
+		for _, chainID := range list {
+			chainID = strings.ToLower(chainID)
+			if !isChainID(chainID) || seen[chainID] {
+				continue
+			}
+			seen[chainID] = true
+			chainIDs = append(chainIDs, chainID)
+		}
+	}
+	return chainIDs
+}
+
+// isChainID returns true if s is a 32 byte hex encoded string.
+func isChainID(s string) bool {
+	if len(s) != 64 {
+		return false
+	}
+	_, err := hex.DecodeString(s)
+	return err == nil
+}
+
+func equalStrings(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// loadChainHistory reads the chain IDs saved in the local chain history file.
+func loadChainHistory() []string {
+	f, err := os.Open(chainHistoryPath())
+	if err != nil {
+		if !os.IsNotExist(err) {
+			complete.Log("error: %v", err)
+		}
+		return nil
+	}
+	defer f.Close()
+
+	var chainIDs []string
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		if line := strings.TrimSpace(scanner.Text()); len(line) > 0 {
+			chainIDs = append(chainIDs, line)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		complete.Log("error: %v", err)
+	}
+	return chainIDs
+}
+
+// saveChainHistory overwrites the local chain history file with chainIDs.
+func saveChainHistory(chainIDs []string) {
+	path := chainHistoryPath()
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		complete.Log("error: %v", err)
+		return
+	}
+	data := strings.Join(chainIDs, "\n") + "\n"
+	if err := writeFileAtomic(path, []byte(data)); err != nil {
+		complete.Log("error: %v", err)
+	}
+}
+
+// writeFileAtomic writes data to a temporary file and then renames it to path
+// so that concurrent completions never read a partially written file.
+func writeFileAtomic(path string, data []byte) error {
+	f, err := ioutil.TempFile(filepath.Dir(path), ".tmp-")
+	if err != nil {
+		return err
+	}
+	tmp := f.Name()
+	if _, err := f.Write(data); err != nil {
+		f.Close()
+		os.Remove(tmp)
+		return err
+	}
+	if err := f.Close(); err != nil {
+		os.Remove(tmp)
+		return err
+	}
+	return os.Rename(tmp, path)
+}
+
+func chainHistoryPath() string {
+	return filepath.Join(dataDir(), "chains")
+}
+
+// dataDir returns the directory in which complete-factom-cli stores its files.
+func dataDir() string {
+	return filepath.Join(homeDir(), ".factom", "complete-factom-cli")
+}
+
+func homeDir() string {
+	if home := os.Getenv("HOME"); len(home) > 0 {
+		return home
+	}
+	u, err := user.Current()
+	if err != nil {
+		complete.Log("error: %v", err)
+		return ""
+	}
+	return u.HomeDir
+}
@@ -0,0 +1,177 @@
+package factomcli
+
+import (
+	"encoding/json"
+	"sync"
+	"sync/atomic"
+
+	"github.com/AdamSLevy/factom"
+)
+
+// Client is the part of the factom-walletd and factomd APIs that the
+// predictors use. Its methods behave like the functions of the same name in
+// github.com/AdamSLevy/factom. GetFactoidBalance and GetECBalance are called
+// concurrently.
+type Client interface {
+	// factom-walletd
+	FetchAddresses() ([]*factom.FactoidAddress, []*factom.ECAddress, error)
+	ListTransactionsTmp() ([]*factom.Transaction, error)
+	ListTransactionsAll() ([]*factom.Transaction, error)
+	ListTransactionsAddress(address string) ([]*factom.Transaction, error)
+	GetWalletHeight() (uint32, error)
+
+	// factomd
+	GetFactoidBalance(address string) (int64, error)
+	GetECBalance(address string) (int64, error)
+	GetRate() (uint64, error)
+	GetHeights() (*factom.HeightsResponse, error)
+	GetPendingEntries() (string, error)
+	GetPendingTransactions() (string, error)
+	GetDBlockHead() (string, error)
+	GetDBlock(keymr string) (*factom.DBlock, error)
+	GetChainHead(chainID string) (string, error)
+	GetEBlock(keymr string) (*factom.EBlock, error)
+	GetFirstEntry(chainID string) (*factom.Entry, error)
+}
+
+// predictors fetch the data of their predictions from client.
+type predictors struct {
+	client Client
+}
+
+// rpcPredictors query the servers given by the factom-cli flags on the
+// command line. The exported predictors are theirs.
+var rpcPredictors = predictors{rpcClient{}}
+
+// rpcClient is the default Client. It calls factom-walletd and factomd over
+// JSON-RPC as configured by the factom-cli flags on the command line.
+type rpcClient struct{}
+
+// rpcMu serializes the calls of rpcClient. The factom package numbers its
+// requests with an unsynchronized counter. Balances are requested with IDs
+// of their own and only hold a read lock, so they are fetched concurrently,
+// but the connection is not configured while any call is running.
+var rpcMu sync.RWMutex
+
+// balanceID numbers the balance requests.
+var balanceID int64
+
+// lockRPC configures the connection while holding rpcMu and returns the
+// function that releases it.
+func lockRPC() func() {
+	rpcMu.Lock()
+	parseConnectionFlags()
+	return rpcMu.Unlock
+}
+
+func (rpcClient) FetchAddresses() ([]*factom.FactoidAddress,
+	[]*factom.ECAddress, error) {
+	defer lockRPC()()
+	return factom.FetchAddresses()
+}
+
+func (rpcClient) ListTransactionsTmp() ([]*factom.Transaction, error) {
+	defer lockRPC()()
+	return factom.ListTransactionsTmp()
+}
+
+func (rpcClient) ListTransactionsAll() ([]*factom.Transaction, error) {
+	defer lockRPC()()
+	return factom.ListTransactionsAll()
+}
+
+func (rpcClient) ListTransactionsAddress(address string) (
+	[]*factom.Transaction, error) {
+	defer lockRPC()()
+	return factom.ListTransactionsAddress(address)
+}
+
+func (rpcClient) GetWalletHeight() (uint32, error) {
+	defer lockRPC()()
+	return factom.GetWalletHeight()
+}
+
+func (rpcClient) GetFactoidBalance(address string) (int64, error) {
+	return getBalance("factoid-balance", address)
+}
+
+func (rpcClient) GetECBalance(address string) (int64, error) {
+	return getBalance("entry-credit-balance", address)
+}
+
+// getBalance requests the balance of address from factomd with the API
+// method, like factom.GetFactoidBalance and factom.GetECBalance do, but
+// without their request counter.
+func getBalance(method, address string) (int64, error) {
+	rpcMu.RLock()
+	if !connConfigured {
+		rpcMu.RUnlock()
+		lockRPC()()
+		rpcMu.RLock()
+	}
+	defer rpcMu.RUnlock()
+	params := struct {
+		Address string `json:"address"`
+	}{address}
+	req := factom.NewJSON2Request(method, atomic.AddInt64(&balanceID, 1),
+		params)
+	resp, err := factom.SendFactomdRequest(req)
+	if err != nil {
+		return -1, err
+	}
+	if resp.Error != nil {
+		return -1, resp.Error
+	}
+	var balance struct {
+		Balance int64 `json:"balance"`
+	}
+	if err := json.Unmarshal(resp.JSONResult(), &balance); err != nil {
+		return -1, err
+	}
+	return balance.Balance, nil
+}
+
+func (rpcClient) GetRate() (uint64, error) {
+	defer lockRPC()()
+	return factom.GetRate()
+}
+
+func (rpcClient) GetHeights() (*factom.HeightsResponse, error) {
+	defer lockRPC()()
+	return factom.GetHeights()
+}
+
+func (rpcClient) GetPendingEntries() (string, error) {
+	defer lockRPC()()
+	return factom.GetPendingEntries()
+}
+
+func (rpcClient) GetPendingTransactions() (string, error) {
+	defer lockRPC()()
+	return factom.GetPendingTransactions()
+}
+
+func (rpcClient) GetDBlockHead() (string, error) {
+	defer lockRPC()()
+	return factom.GetDBlockHead()
+}
+
+func (rpcClient) GetDBlock(keymr string) (*factom.DBlock, error) {
+	defer lockRPC()()
+	return factom.GetDBlock(keymr)
+}
+
+func (rpcClient) GetChainHead(chainID string) (string, error) {
+	defer lockRPC()()
+	return factom.GetChainHead(chainID)
+}
+
+func (rpcClient) GetEBlock(keymr string) (*factom.EBlock, error) {
+	defer lockRPC()()
+	return factom.GetEBlock(keymr)
+}
+
+func (rpcClient) GetFirstEntry(chainID string) (*factom.Entry, error) {
+	defer lockRPC()()
+	return factom.GetFirstEntry(chainID)
+}
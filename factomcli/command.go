@@ -0,0 +1,113 @@
+package factomcli
+
+import (
+	"github.com/posener/complete"
+)
+
+// newCLI returns the completion of the given version of factom-cli whose
+// predictors query c. The most recent version is completed if version is "".
+func newCLI(version string, c Client) *CLI {
+	specs := selectSpecs(factomCLISpecs, version)
+	cmd, rules := buildCommand(specs, factomCLIPredictors(predictors{c}),
+		factomCLIRules(specs))
+	return &CLI{
+		Command: cmd,
+		rules:   rules,
+		help:    newCommandHelp(specs),
+		version: version,
+		client:  c,
+	}
+}
+
+// factomCLIPredictors returns the predictors of the flags and arguments of
+// factom-cli by the names they have in its usage text.
+func factomCLIPredictors(p predictors) predictorTable {
+	addresses := complete.PredictFunc(p.address)
+	fctAddresses := complete.PredictFunc(p.fctAddress)
+	ecAddresses := complete.PredictFunc(p.ecAddress)
+	txIDs := complete.PredictFunc(p.txID)
+	entryHashes := complete.PredictFunc(p.entryHash)
+	return predictorTable{
+		"-factomdcert":     predictFiles("*"),
+		"-factomdpassword": complete.PredictAnything,
+		"-factomduser":     complete.PredictAnything,
+		"-s":               complete.PredictAnything,
+		"-w":               complete.PredictAnything,
+		"-walletcert":      predictFiles("*"),
+		"-walletpassword":  complete.PredictAnything,
+		"-walletuser":      complete.PredictAnything,
+
+		"-n": complete.PredictFunc(p.chainName),
+		"-h": complete.PredictFunc(p.hexChainName),
+		"-c": complete.PredictFunc(p.listChainIDs),
+		"-e": complete.PredictAnything,
+		"-x": complete.PredictAnything,
+
+		"12WORDS": PredictMnemonic,
+
+		"ADDRESS":               listedAddresses(addresses),
+		"addtxecoutput ADDRESS": listedEC(ecAddresses),
+		"addtxfee ADDRESS":      listedFCT(PredictArgsFunc(p.txInputAddress)),
+		"addtxinput ADDRESS":    listedFCT(PredictArgsFunc(p.txNewInputAddress)),
+		"addtxoutput ADDRESS":   listedFCT(fctAddresses),
+		"subtxfee ADDRESS":      listedFCT(PredictArgsFunc(p.txOutputAddress)),
+		// importaddress takes secret keys.
+		"importaddress ADDRESS": complete.PredictAnything,
+		"ECADDRESS":             listedEC(ecAddresses),
+		"FCTADDRESS":            listedFCT(fctAddresses),
+		"ECADDRESS|FCTADDRESS":  listedAddresses(addresses),
+		"FROMADDRESS":           listedFCT(fctAddresses),
+		"TOADDRESS":             listedFCT(fctAddresses),
+
+		"addtxecoutput AMOUNT": PredictArgsFunc(p.txECOutputAmount),
+		"addtxinput AMOUNT":    PredictArgsFunc(p.txInputAmount),
+		"addtxoutput AMOUNT":   PredictArgsFunc(p.txOutputAmount),
+		"sendfct AMOUNT":       PredictArgsFunc(p.sendAmount),
+		"ECAMOUNT":             PredictArgsFunc(p.buyECAmount),
+
+		"TXNAME":               listedTxNames(p.txNames(nil)),
+		"addtxecoutput TXNAME": listedTxNames(p.txNames(unsignedTx)),
+		"addtxfee TXNAME":      listedTxNames(p.txNames(unsignedTx)),
+		"addtxinput TXNAME":    listedTxNames(p.txNames(unsignedTx)),
+		"addtxoutput TXNAME":   listedTxNames(p.txNames(unsignedTx)),
+		"subtxfee TXNAME":      listedTxNames(p.txNames(unsignedTx)),
+		"composetx TXNAME":     listedTxNames(p.txNames(signedTx)),
+		"sendtx TXNAME":        listedTxNames(p.txNames(signedTx)),
+		"signtx TXNAME":        listedTxNames(p.txNames(signableTx)),
+		// newtx takes the name of a new transaction.
+		"newtx TXNAME": complete.PredictAnything,
+		"TXID":         txIDs,
+		"TxID|FullTx":  txIDs,
+
+		"CHAINID":          complete.PredictFunc(p.listChainIDs),
+		"HEIGHT":           complete.PredictFunc(p.height),
+		"START":            complete.PredictFunc(p.rangeStart),
+		"END":              PredictArgsFunc(p.rangeEnd),
+		"get dblock KEYMR": complete.PredictFunc(p.dblockKeyMR),
+		"get eblock KEYMR": complete.PredictFunc(p.eblockKeyMR),
+		"get entry HASH":   entryHashes,
+		"get raw HASH":     complete.PredictFunc(p.rawHash),
+		"ENTRYHASH":        entryHashes,
+	}
+}
+
+// outputModes are the flags that select what factom-cli prints. Only one of
+// them may be given.
+var outputModes = []string{"-C", "-E", "-T"}
+
+// factomCLIRules returns the rules of the flags of the commands in specs that
+// the usage text of factom-cli does not show. Its commands parse their flags
+// with the flag package, so the first positional argument ends the option
+// list.
+func factomCLIRules(specs []commandSpec) commandRules {
+	rules := commandRules{
+		"addchain": {exclusive: [][]string{outputModes}},
+		"addentry": {exclusive: [][]string{outputModes}},
+	}
+	for _, spec := range specs {
+		r := rules[spec.Path]
+		r.ends = true
+		rules[spec.Path] = r
+	}
+	return rules
+}
@@ -0,0 +1,741 @@
+package factomcli
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/AdamSLevy/factom"
+)
+
+func TestMain(m *testing.M) {
+	// The cache is refreshed by running the test binary in the background.
+	if refreshCache() {
+		return
+	}
+	os.Exit(m.Run())
+}
+
+// fixture runs completions of factom-cli against a fake factom-walletd and
+// factomd, with a temporary home directory for the cache and the chain
+// history.
+type fixture struct {
+	wallet  *fakeServer
+	factomd *fakeServer
+	home    string
+	env     map[string]string
+	cli     *CLI
+}
+
+// testEnv are the environment variables that a fixture sets.
+var testEnv = []string{"HOME", "COMP_LINE", "COMP_POINT", "COMP_SHELL"}
+
+func newFixture(t *testing.T) *fixture {
+	home, err := ioutil.TempDir("", "complete-factom-cli")
+	if err != nil {
+		t.Fatal(err)
+	}
+	f := &fixture{
+		wallet:  newFakeWallet(),
+		factomd: newFakeFactomd(),
+		home:    home,
+		env:     make(map[string]string),
+	}
+	for _, name := range testEnv {
+		f.env[name] = os.Getenv(name)
+	}
+	f.cli = newCLI("", rpcClient{})
+	return f
+}
+
+// close stops the fake servers and restores the environment.
+func (f *fixture) close() {
+	f.wallet.Close()
+	f.factomd.Close()
+	os.RemoveAll(f.home)
+	for name, value := range f.env {
+		os.Setenv(name, value)
+	}
+}
+
+// complete returns the candidates that shell receives for line, which is
+// typed after the program name and the flags that connect to the fake
+// servers. The cursor is at the end of the line, or at the "|" in line.
+func (f *fixture) complete(shell, line string) []string {
+	return f.completeProgram(shell, fmt.Sprintf("factom-cli -w %v -s %v %v",
+		f.wallet.addr(), f.factomd.addr(), line))
+}
+
+// completeProgram returns the candidates that shell receives for the whole
+// command line of any of the programs.
+func (f *fixture) completeProgram(shell, line string) []string {
+	point := len(line)
+	if i := strings.Index(line, "|"); i >= 0 {
+		line, point = line[:i]+line[i+1:], i
+	}
+	os.Setenv("HOME", f.home)
+	os.Setenv("COMP_LINE", line)
+	os.Setenv("COMP_POINT", fmt.Sprint(point))
+	os.Setenv("COMP_SHELL", shell)
+	// A request that outlived its deadline may still be running.
+	rpcMu.Lock()
+	connConfigured, connArgs = false, nil
+	rpcMu.Unlock()
+
+	p := lineProgram(f.cli.programs())
+	var out bytes.Buffer
+	completeLine(p, newOutput(&out))
+	candidates := strings.Split(out.String(), "\n")
+	return candidates[:len(candidates)-1]
+}
+
+// typeChainID completes a chain ID after the chain of the fake blockchain is
+// typed with -c, which saves it to the chain history, and then a chain name,
+// and waits for the name of the chain to be fetched in the background.
+func (f *fixture) typeChainID(t *testing.T) {
+	t.Helper()
+	f.complete("bash", "addentry -c "+fakeChainID+" -c ")
+	f.complete("bash", "addchain -n ")
+	for start := time.Now(); ; time.Sleep(10 * time.Millisecond) {
+		if _, ok := loadChainNames()[fakeChainID]; ok {
+			return
+		}
+		if time.Since(start) > 5*time.Second {
+			t.Fatal("the chain name was not fetched")
+		}
+	}
+}
+
+// assertCandidates fails the test if got and want do not hold the same
+// candidates, in any order.
+func assertCandidates(t *testing.T, line string, got, want []string) {
+	t.Helper()
+	got, want = append([]string{}, got...), append([]string{}, want...)
+	sort.Strings(got)
+	sort.Strings(want)
+	if len(got) == 0 && len(want) == 0 {
+		return
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("%q: got %q, want %q", line, got, want)
+	}
+}
+
+// TestCommands covers the completion of every factom-cli command in bash,
+// which receives no descriptions.
+func TestCommands(t *testing.T) {
+	f := newFixture(t)
+	defer f.close()
+	f.typeChainID(t)
+
+	fcts := []string{fakeFA1, fakeFA2}
+	ecs := []string{fakeEC1, fakeEC2}
+	all := append(append([]string{}, fcts...), ecs...)
+	heights := []string{"1000", "1001", "999", "998", "997", "996", "995",
+		"994", "993", "992", "991", "990"}
+	entries := []string{fakePendingEntry, fakeFirstEntry, fakeEntry}
+	txNames := []string{"empty", "ready", "signed"}
+	tests := []struct {
+		line string
+		want []string
+	}{
+		{"add", []string{"addchain", "addentry", "addtxecoutput",
+			"addtxfee", "addtxinput", "addtxoutput"}},
+		{"-", []string{"-factomdcert", "-factomdpassword", "-factomdtls",
+			"-factomduser", "-walletcert", "-walletpassword", "-wallettls",
+			"-walletuser"}},
+
+		{"addchain ", ecs},
+		{"addchain -", []string{"-f", "-q", "-n", "-h", "-C", "-E", "-T"}},
+		{"addchain -n ", []string{"myapp"}},
+		{"addchain -n myapp -n ", []string{"users"}},
+		{"addchain -n myapp -h ",
+			[]string{hex.EncodeToString([]byte("users"))}},
+		{"addchain -h 6d", []string{hex.EncodeToString([]byte("myapp"))}},
+		{"addchain -n other -n ", nil},
+		{"addchain -fq -C -", []string{"-n", "-h"}},
+		{"addchain " + fakeEC1 + " -", nil},
+		{"addentry ", ecs},
+		{"addentry -c ", []string{fakeChainID}},
+		{"addentry -n myapp -", []string{"-f", "-q", "-n", "-h", "-e", "-x",
+			"-C", "-E", "-T"}},
+		{"addentry -c " + fakeChainID + " -", []string{"-f", "-q", "-e",
+			"-x", "-C", "-E", "-T"}},
+		{"addentry -- -", nil},
+		{"addtxecoutput ", []string{"empty", "ready"}},
+		{"addtxecoutput ready ", ecs},
+		{"addtxecoutput ready " + fakeEC1 + " ", []string{"0.99978"}},
+		{"addtxfee ", []string{"empty", "ready"}},
+		{"addtxfee ready ", []string{fakeFA1}},
+		{"addtxinput ready ", nil},
+		{"addtxinput empty ", []string{fakeFA1}},
+		{"addtxinput empty " + fakeFA1 + " ", []string{"0.00002", "5"}},
+		{"addtxinput ready " + fakeFA1 + " ", []string{"5"}},
+		{"addtxoutput -r ready ", fcts},
+		{"addtxoutput ready " + fakeFA2 + " ", []string{"0.99978"}},
+		{"backupwallet ", nil},
+		{"balance ", all},
+		{"balance -r FA2B", []string{fakeFA1}},
+		{"buyec ", fcts},
+		{"buyec " + fakeFA1 + " ", ecs},
+		{"buyec " + fakeFA1 + " " + fakeEC1 + " ", []string{"499988"}},
+		{"composechain ", ecs},
+		{"composechain -n ", []string{"myapp"}},
+		{"composeentry -c ", []string{fakeChainID}},
+		{"composeentry -n myapp -", []string{"-f", "-n", "-h", "-e", "-x"}},
+		{"composetx ", []string{"signed"}},
+		{"ecrate ", nil},
+		{"exportaddresses ", nil},
+		{"help add", []string{"addchain", "addentry", "addtxecoutput",
+			"addtxfee", "addtxinput", "addtxoutput"}},
+
+		{"get ", []string{"abheight", "allentries", "chainhead",
+			"dbheight", "dblock", "eblock", "ecbheight", "entry",
+			"fbheight", "firstentry", "head", "heights",
+			"pendingentries", "pendingtransactions", "raw",
+			"walletheight"}},
+		{"get abheight ", heights},
+		{"get allentries ", []string{fakeChainID}},
+		{"get allentries -n ", []string{"myapp"}},
+		{"get allentries -n myapp ", nil},
+		{"get allentries -n myapp -", []string{"-n", "-h", "-E"}},
+		{"get chainhead ", []string{fakeChainID}},
+		{"get chainhead -n myapp ", nil},
+		{"get chainhead " + fakeChainID + " -", nil},
+		{"get chainhead -", []string{"-n", "-h", "-K"}},
+		{"get dbheight -r ", heights},
+		{"get dblock ", []string{fakeDBlockHead, fakeDBlockPrev}},
+		{"get eblock ", []string{fakeEBlock}},
+		{"get ecbheight ", heights},
+		{"get entry ", entries},
+		{"get fbheight ", heights},
+		{"get firstentry -h ",
+			[]string{hex.EncodeToString([]byte("myapp"))}},
+		{"get head -", []string{"-K"}},
+		{"get heights ", nil},
+		{"get pendingentries -", []string{"-E"}},
+		{"get pendingtransactions -", []string{"-T"}},
+		{"get raw ", append([]string{fakeDBlockHead, fakeDBlockPrev,
+			fakeEBlock}, entries...)},
+		{"get walletheight ", nil},
+
+		{"importkoinify 'abandon zo",
+			[]string{"abandon zone", "abandon zoo"}},
+		{"importkoinify abandon\\ zo", []string{"abandon\\ zone",
+			"abandon\\ zoo"}},
+		{"listaddresses ", nil},
+		{"listtxs ", []string{"address", "all", "id", "name", "tmp",
+			"range"}},
+		{"listtxs address ", all},
+		{"listtxs all -", []string{"-T"}},
+		{"listtxs id ",
+			[]string{fakePendingTxID, fakeNewTxID, fakeOldTxID}},
+		{"listtxs name ", txNames},
+		{"listtxs range ", []string{"990", "989", "988", "987", "986",
+			"985", "984", "983", "982", "981", "980", "0"}},
+		{"listtxs range 995 ", []string{"1000", "1005"}},
+		{"listtxs tmp ", nil},
+		{"newecaddress ", nil},
+		{"newfctaddress ", nil},
+		{"newtx -", []string{"-q"}},
+		{"properties ", nil},
+		{"receipt ", entries},
+		{"rmaddress ", all},
+		{"rmtx ", txNames},
+		{"sendfct ", fcts},
+		{"sendfct " + fakeFA1 + " ", fcts},
+		{"sendfct -r " + fakeFA1 + " " + fakeFA2 + " ",
+			[]string{"4.99988"}},
+		{"sendfct -r -", []string{"-f", "-q", "-T"}},
+		{"sendtx ", []string{"signed"}},
+		{"signtx ", []string{"ready"}},
+		{"status ", []string{fakePendingTxID, fakeNewTxID, fakeOldTxID}},
+		{"subtxfee ready ", []string{fakeFA2}},
+	}
+	for _, test := range tests {
+		assertCandidates(t, test.line, f.complete("bash", test.line),
+			test.want)
+	}
+}
+
+// TestDescriptions checks the candidate descriptions and their order in
+// fish.
+func TestDescriptions(t *testing.T) {
+	f := newFixture(t)
+	defer f.close()
+
+	tests := []struct {
+		line string
+		want []string
+	}{
+		{"balance ", []string{
+			fakeFA1 + "\t5 FCT",
+			fakeFA2 + "\t0 FCT",
+			fakeEC1 + "\t1000 EC",
+			fakeEC2 + "\t5 EC",
+		}},
+		{"rmtx ", []string{
+			"empty\tin: 0 FCT, out: 0 FCT, fee: 0 FCT, unsigned",
+			"ready\tin: 2 FCT, out: 1 FCT, fee: 0 FCT, unsigned",
+			"signed\tin: 2 FCT, out: 1 FCT, fee: 0 FCT, signed",
+		}},
+		{"status ", []string{
+			fakePendingTxID + "\t1.5 FCT, pending",
+			fakeNewTxID + "\t2 FCT, " + localTime(1535796000),
+			fakeOldTxID + "\t3 FCT, " + localTime(1533117600),
+		}},
+		{"get dblock ", []string{
+			fakeDBlockHead + "\tdirectory block 1000",
+			fakeDBlockPrev + "\tdirectory block 999",
+		}},
+		{"get abheight 100", []string{
+			"1000\tdirectory block height",
+			"1001\tleader height",
+		}},
+	}
+	for _, test := range tests {
+		got := f.complete("fish", test.line)
+		if !reflect.DeepEqual(got, test.want) {
+			t.Errorf("%q: got %q, want %q", test.line, got, test.want)
+		}
+	}
+}
+
+func localTime(unix int64) string {
+	return time.Unix(unix, 0).Format(txIDTimeFormat)
+}
+
+// TestShellFormats checks that the candidates are written in the format of
+// each shell, with sub commands and flags described by their usage and the
+// candidates grouped for zsh.
+func TestShellFormats(t *testing.T) {
+	f := newFixture(t)
+	defer f.close()
+
+	tests := []struct {
+		shell string
+		line  string
+		want  []string
+	}{
+		{"zsh", "balance ", []string{
+			"FCT addresses\t" + fakeFA1 + ":5 FCT",
+			"FCT addresses\t" + fakeFA2 + ":0 FCT",
+			"EC addresses\t" + fakeEC1 + ":1000 EC",
+			"EC addresses\t" + fakeEC2 + ":5 EC",
+		}},
+		{"zsh", "rmtx e", []string{
+			"tmp transactions\tempty:in: 0 FCT, out: 0 FCT, fee: 0 FCT, unsigned",
+		}},
+		{"zsh", "get h", []string{
+			"commands\thead:[-K]",
+			"commands\theights:[-DLBE]",
+		}},
+		{"zsh", "addtxinput -", []string{"flags\t-q"}},
+		{"zsh", "-walletu", []string{
+			"flags\t-walletuser:username for API connections to factom-walletd",
+		}},
+		{"fish", "get h", []string{"head\t[-K]", "heights\t[-DLBE]"}},
+		{"fish", "addtxinput -", []string{"-q\tflags"}},
+		{"bash", "get h", []string{"head", "heights"}},
+	}
+	for _, test := range tests {
+		got := f.complete(test.shell, test.line)
+		assertCandidates(t, test.shell+" "+test.line, got, test.want)
+	}
+
+	got := zshCandidate(describe("host:port", "address"))
+	if want := "values\thost\\:port:address"; got != want {
+		t.Errorf("zsh escaping: got %q, want %q", got, want)
+	}
+}
+
+// TestMnemonicChecksum checks that the 12th word of a mnemonic is described
+// by whether the checksum is valid, valid ones first.
+func TestMnemonicChecksum(t *testing.T) {
+	f := newFixture(t)
+	defer f.close()
+
+	typed := strings.Repeat("abandon ", 11)
+	got := f.complete("fish", "importkoinify '"+typed+"ab")
+	want := []string{
+		typed + "about\tvalid checksum",
+		typed + "abandon\tinvalid checksum, check the words and their order",
+		typed + "ability\tinvalid checksum, check the words and their order",
+		typed + "able\tinvalid checksum, check the words and their order",
+		typed + "above\tinvalid checksum, check the words and their order",
+		typed + "absent\tinvalid checksum, check the words and their order",
+		typed + "absorb\tinvalid checksum, check the words and their order",
+		typed + "abstract\tinvalid checksum, check the words and their order",
+		typed + "absurd\tinvalid checksum, check the words and their order",
+		typed + "abuse\tinvalid checksum, check the words and their order",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
+
+// TestTxFee checks that the fee counts the RCD and signature of each input
+// toward the size of the transaction.
+func TestTxFee(t *testing.T) {
+	tests := []struct {
+		inputs, outputs, ecOutputs int
+		want                       uint64
+	}{
+		{1, 1, 0, 12},
+		{1, 0, 1, 12},
+		{7, 1, 0, 18},
+		{8, 1, 0, 20},
+	}
+	for _, test := range tests {
+		got := txFee(1, test.inputs, test.outputs, test.ecOutputs)
+		if got != test.want {
+			t.Errorf("%d inputs, %d outputs, %d EC outputs: got %d EC, "+
+				"want %d EC", test.inputs, test.outputs, test.ecOutputs,
+				got, test.want)
+		}
+	}
+}
+
+// TestCursor checks that only the line up to COMP_POINT is completed.
+func TestCursor(t *testing.T) {
+	f := newFixture(t)
+	defer f.close()
+
+	line := "balance FA3| " + fakeFA1
+	assertCandidates(t, line, f.complete("bash", line), []string{fakeFA2})
+	line = "get d| dblock"
+	assertCandidates(t, line, f.complete("bash", line),
+		[]string{"dbheight", "dblock"})
+}
+
+// TestWalletErrors checks that nothing is offered when factom-walletd
+// returns errors, and that cached data is used once it was fetched.
+func TestWalletErrors(t *testing.T) {
+	f := newFixture(t)
+	defer f.close()
+
+	f.wallet.fail("tmp-transactions")
+	f.wallet.fail("all-addresses")
+	for _, line := range []string{"rmtx ", "balance ", "addtxfee ready "} {
+		assertCandidates(t, line, f.complete("bash", line), nil)
+	}
+
+	f = newFixture(t)
+	defer f.close()
+	assertCandidates(t, "rmtx ", f.complete("bash", "rmtx "),
+		[]string{"empty", "ready", "signed"})
+	f.wallet.fail("tmp-transactions")
+	assertCandidates(t, "rmtx ", f.complete("bash", "rmtx "),
+		[]string{"empty", "ready", "signed"})
+	if n := f.wallet.called("tmp-transactions"); n != 1 {
+		t.Errorf("tmp-transactions called %v times, want 1", n)
+	}
+}
+
+// TestCacheServers checks that data cached from one factomd is not offered
+// for another.
+func TestCacheServers(t *testing.T) {
+	f := newFixture(t)
+	defer f.close()
+	other := newFakeFactomd()
+	defer other.Close()
+	other.fail("heights")
+
+	line := func(factomd *fakeServer) string {
+		return fmt.Sprintf("factom-cli -w %v -s %v get dbheight ",
+			f.wallet.addr(), factomd.addr())
+	}
+	if got := f.completeProgram("bash", line(f.factomd)); len(got) == 0 {
+		t.Errorf("%q: no candidates", line(f.factomd))
+	}
+	assertCandidates(t, line(other), f.completeProgram("bash", line(other)),
+		nil)
+	if n := other.called("heights"); n != 1 {
+		t.Errorf("heights called %v times, want 1", n)
+	}
+}
+
+// TestBlocksCached checks that the blocks are walked only once within their
+// TTL.
+func TestBlocksCached(t *testing.T) {
+	f := newFixture(t)
+	defer f.close()
+	f.typeChainID(t)
+	// Fetching the name of the chain walked it already.
+	walked := f.factomd.called("chain-head")
+
+	for i := 0; i < 2; i++ {
+		if got := f.complete("bash", "get raw "); len(got) == 0 {
+			t.Errorf("get raw: no candidates")
+		}
+	}
+	if n := f.factomd.called("chain-head") - walked; n != 1 {
+		t.Errorf("chain-head called %v times, want 1", n)
+	}
+	if n := f.factomd.called("directory-block-head"); n != 1 {
+		t.Errorf("directory-block-head called %v times, want 1", n)
+	}
+}
+
+// TestChainHistory checks that only the chain IDs typed with -c are saved to
+// the chain history and that the chains of pending entries are offered after
+// them without being walked.
+func TestChainHistory(t *testing.T) {
+	f := newFixture(t)
+	defer f.close()
+
+	typed := strings.Repeat("c1", 32)
+	var mu sync.Mutex
+	walked := make(map[string]bool)
+	f.factomd.handle("chain-head", func(params json.RawMessage) (interface{},
+		*factom.JSONError) {
+		var p map[string]string
+		json.Unmarshal(params, &p)
+		mu.Lock()
+		walked[p["chainid"]] = true
+		mu.Unlock()
+		return nil, factom.NewJSONError(-32009, "Missing Chain Head", nil)
+	})
+
+	assertCandidates(t, "get allentries ", f.complete("bash",
+		"get allentries "), []string{fakeChainID})
+	if got := loadChainHistory(); len(got) != 0 {
+		t.Errorf("history: got %q, want none", got)
+	}
+	line := "addentry -c " + typed + " -c "
+	if got, want := f.complete("bash", line),
+		[]string{typed, fakeChainID}; !reflect.DeepEqual(got, want) {
+		t.Errorf("%q: got %q, want %q", line, got, want)
+	}
+	if got, want := loadChainHistory(), []string{typed}; !reflect.DeepEqual(
+		got, want) {
+		t.Errorf("history: got %q, want %q", got, want)
+	}
+	f.complete("bash", "get raw ")
+	f.complete("bash", "addchain -n ")
+	mu.Lock()
+	defer mu.Unlock()
+	if walked[fakeChainID] {
+		t.Errorf("the chain of the pending entry was walked")
+	}
+}
+
+// slowFirstEntryClient fetches the first entry of the chain of the fake
+// blockchain at once and never returns that of any other chain.
+type slowFirstEntryClient struct {
+	rpcClient
+}
+
+func (slowFirstEntryClient) GetFirstEntry(chainID string) (*factom.Entry,
+	error) {
+	if chainID != fakeChainID {
+		select {}
+	}
+	return &factom.Entry{ChainID: chainID,
+		ExtIDs: [][]byte{[]byte("myapp")}}, nil
+}
+
+// TestChainNamesTimeout checks that the names of chains that were fetched
+// before the deadline are saved when the first entry of another chain takes
+// too long.
+func TestChainNamesTimeout(t *testing.T) {
+	f := newFixture(t)
+	defer f.close()
+	os.Setenv("HOME", f.home)
+
+	slow := strings.Repeat("c1", 32)
+	saveChainHistory([]string{fakeChainID, slow})
+	start := time.Now()
+	fetchChainNames(slowFirstEntryClient{}, 100*time.Millisecond)
+	if d := time.Since(start); d > time.Second {
+		t.Errorf("took %v", d)
+	}
+	names := loadChainNames()
+	if got, want := names[fakeChainID],
+		[]string{hex.EncodeToString([]byte("myapp"))}; !reflect.DeepEqual(
+		got, want) {
+		t.Errorf("name: got %q, want %q", got, want)
+	}
+	if _, ok := names[slow]; ok {
+		t.Errorf("the slow chain has a name")
+	}
+}
+
+// TestFactomdErrors checks that predictors that depend on factomd offer
+// nothing when it returns errors.
+func TestFactomdErrors(t *testing.T) {
+	f := newFixture(t)
+	defer f.close()
+
+	for _, method := range []string{"heights", "directory-block-head",
+		"entry-credit-rate", "pending-entries", "pending-transactions"} {
+		f.factomd.fail(method)
+	}
+	for _, line := range []string{"get abheight ", "get dblock ",
+		"sendfct -r " + fakeFA1 + " " + fakeFA2 + " ", "receipt "} {
+		assertCandidates(t, line, f.complete("bash", line), nil)
+	}
+	// Transactions of the wallet are still offered.
+	assertCandidates(t, "status ", f.complete("bash", "status "),
+		[]string{fakeNewTxID, fakeOldTxID})
+}
+
+// TestTimeouts checks that completion gives up on servers that do not
+// respond in time.
+func TestTimeouts(t *testing.T) {
+	if testing.Short() {
+		t.Skip("waits for timeouts")
+	}
+	f := newFixture(t)
+	defer f.close()
+
+	// The balances are requested at once, so all of them are in time
+	// although requesting them one after the other would not be.
+	f.factomd.setDelay(balanceTimeout / 3)
+	got := f.complete("fish", "balance ")
+	want := []string{
+		fakeFA1 + "\t5 FCT",
+		fakeFA2 + "\t0 FCT",
+		fakeEC1 + "\t1000 EC",
+		fakeEC2 + "\t5 EC",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("balance: got %q, want %q", got, want)
+	}
+
+	// The wallet responds in time, but factomd does not respond before
+	// the balances are due.
+	f.factomd.setDelay(balanceTimeout + 100*time.Millisecond)
+	start := time.Now()
+	got = f.complete("fish", "balance ")
+	if elapsed := time.Since(start); elapsed > 2*balanceTimeout {
+		t.Errorf("balance took %v", elapsed)
+	}
+	assertCandidates(t, "balance ", got, []string{
+		fakeFA1 + "\tFCT addresses",
+		fakeFA2 + "\tFCT addresses",
+		fakeEC1 + "\tEC addresses",
+		fakeEC2 + "\tEC addresses",
+	})
+
+	// Neither server responds before the connection timeout.
+	f.factomd.setDelay(2 * time.Second)
+	f.wallet.setDelay(2 * time.Second)
+	start = time.Now()
+	got = f.complete("bash", "get dblock ")
+	if elapsed := time.Since(start); elapsed > 2*time.Second {
+		t.Errorf("get dblock took %v", elapsed)
+	}
+	assertCandidates(t, "get dblock ", got, nil)
+}
+
+// TestServers covers the completion of factomd and factom-walletd, which
+// are told apart from factom-cli by the first word of the line.
+func TestServers(t *testing.T) {
+	f := newFixture(t)
+	defer f.close()
+
+	dir := filepath.Join(f.home, ".factom")
+	for _, name := range []string{"factomd.conf", "factom_wallet.db"} {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := ioutil.WriteFile(path, nil, 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	tests := []struct {
+		line string
+		want []string
+	}{
+		{"factomd -network ", []string{"MAIN", "TEST", "LOCAL", "CUSTOM"}},
+		{"/usr/local/bin/factomd -db ", []string{"Map", "LDB", "Bolt"}},
+		{"factomd -loglvl=w", []string{"warning"}},
+		{"factomd -tls -net a", []string{"alot", "alot+"}},
+		{"factomd -config " + dir + "/",
+			[]string{dir + "/", filepath.Join(dir, "factomd.conf")}},
+		{"factomd -config=" + dir + "/",
+			[]string{dir + "/", filepath.Join(dir, "factomd.conf")}},
+		{"factomd -fa", []string{"-factomhome", "-fast", "-fastlocation",
+			"-faulttimeout"}},
+		{"factom-walletd -w " + dir + "/",
+			[]string{dir + "/", filepath.Join(dir, "factomd.conf"),
+				filepath.Join(dir, "factom_wallet.db")}},
+		{"factom-walletd -l -", []string{"-cert", "-e", "-factomdcert",
+			"-factomdpassword", "-factomdtls", "-factomduser", "-i",
+			"-key", "-p", "-password", "-s", "-tls", "-user", "-w"}},
+		{"factom-walletd ", nil},
+		{"factom-cli get he", []string{"head", "heights"}},
+	}
+	for _, test := range tests {
+		assertCandidates(t, test.line,
+			f.completeProgram("bash", test.line), test.want)
+	}
+}
+
+// stubClient serves tmp transactions without a factom-walletd.
+type stubClient struct {
+	rpcClient
+	txs []*factom.Transaction
+}
+
+func (c stubClient) ListTransactionsTmp() ([]*factom.Transaction, error) {
+	return c.txs, nil
+}
+
+// TestWithClient checks that the predictors query the client given to New
+// and that other completions still query the servers.
+func TestWithClient(t *testing.T) {
+	f := newFixture(t)
+	defer f.close()
+
+	stubbed := New(WithClient(stubClient{txs: []*factom.Transaction{
+		{Name: "stubbed"},
+	}}))
+	f.cli = stubbed
+	assertCandidates(t, "rmtx ", f.complete("bash", "rmtx "),
+		[]string{"stubbed"})
+
+	f.cli = newCLI("", rpcClient{})
+	assertCandidates(t, "rmtx ", f.complete("bash", "rmtx "),
+		[]string{"empty", "ready", "signed"})
+	f.cli = stubbed
+	assertCandidates(t, "rmtx ", f.complete("bash", "rmtx "),
+		[]string{"stubbed"})
+}
+
+// TestRunFlags checks that run parses the arguments it is given with its own
+// flags, so that it may run more than once and leaves the flags of the
+// program that embeds it alone.
+func TestRunFlags(t *testing.T) {
+	programs := newCLI("", rpcClient{}).programs()
+	for i := 0; i < 2; i++ {
+		var buf bytes.Buffer
+		err := run(programs, []string{"-script", "bash"}, &buf)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !strings.Contains(buf.String(), "complete -F _factom_cli_static") {
+			t.Errorf("-script bash wrote no bash script:\n%s", &buf)
+		}
+	}
+	if flag.Lookup("script") != nil {
+		t.Error("-script is defined on flag.CommandLine")
+	}
+	if err := run(programs, []string{"-script", "csh"},
+		ioutil.Discard); err == nil {
+		t.Error("no error for csh")
+	}
+}
@@ -0,0 +1,344 @@
+package factomcli
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/posener/complete"
+)
+
+// configPath returns the path of the configuration file of
+// complete-factom-cli. It looks like this:
+//
+//	[walletd]
+//	server = localhost:8089
+//	tls = false
+//	cert = ~/.factom/walletAPIpub.cert
+//	user = me
+//	password = secret
+//	timeout = 1s
+//
+//	[factomd]
+//	server = localhost:8088
+//	timeout = 2s
+//
+//	[cache]
+//	addresses = 10m
+//
+//	[predictors]
+//	balances = false
+//
+// The [factomd] section takes the same keys as [walletd]. The [cache] section
+// sets the TTL of each kind of cached data and the [predictors] section
+// enables or disables the predictors that fetch data.
+func configPath() string {
+	return filepath.Join(dataDir(), "config")
+}
+
+// factomdConfigPath returns the path of the factomd.conf file from which
+// factom-cli reads its defaults.
+func factomdConfigPath() string {
+	return filepath.Join(homeDir(), ".factom", "m2", "factomd.conf")
+}
+
+// configKeys maps the keys of the connection sections of the configuration
+// file to the names of the settings of connConfig.
+var configKeys = map[string]map[string]string{
+	"walletd": {
+		"server":   "w",
+		"tls":      "wallettls",
+		"cert":     "walletcert",
+		"user":     "walletuser",
+		"password": "walletpassword",
+		"timeout":  "wallettimeout",
+	},
+	"factomd": {
+		"server":   "s",
+		"tls":      "factomdtls",
+		"cert":     "factomdcert",
+		"user":     "factomduser",
+		"password": "factomdpassword",
+		"timeout":  "factomdtimeout",
+	},
+}
+
+// factomdConfigKeys maps the keys of factomd.conf that factom-cli uses to the
+// names of the settings of connConfig.
+var factomdConfigKeys = map[string]map[string]string{
+	"walletd": {
+		"walletdlocation":     "w",
+		"wallettlsenable":     "wallettls",
+		"wallettlspubliccert": "walletcert",
+		"walletrpcuser":       "walletuser",
+		"walletrpcpass":       "walletpassword",
+		"factomdlocation":     "s",
+	},
+	"app": {
+		"factomdtlsenabled":    "factomdtls",
+		"factomdtlspubliccert": "factomdcert",
+		"factomdrpcuser":       "factomduser",
+		"factomdrpcpass":       "factomdpassword",
+	},
+}
+
+// predictorNames are the predictors that fetch data and may be disabled in
+// the [predictors] section of the configuration file.
+var predictorNames = []string{cacheAddresses, cacheTmpTxs,
+	cachePendingEntries, cacheHeights, cacheTxIDs, cacheDBlocks, cacheEBlocks,
+	"balances"}
+
+// disabledPredictors holds the predictors that the configuration file
+// disabled.
+var disabledPredictors = make(map[string]bool)
+
+// predictorEnabled returns whether the predictor name may fetch data.
+func predictorEnabled(name string) bool {
+	return !disabledPredictors[name]
+}
+
+// readConfigFiles overrides cfg with the settings of factomd.conf and then
+// with those of the configuration file of complete-factom-cli, which also
+// sets the cache TTLs and the enabled predictors. Missing files are ignored.
+func (cfg *connConfig) readConfigFiles() {
+	disabledPredictors = make(map[string]bool)
+	if ini, ok := readConfigFile(factomdConfigPath()); ok {
+		cfg.setConfig(ini, factomdConfigKeys, "config "+factomdConfigPath(),
+			false)
+	}
+	ini, ok := readConfigFile(configPath())
+	if !ok {
+		return
+	}
+	source := "config " + configPath()
+	cfg.setConfig(ini, configKeys, source, true)
+	for key, value := range ini["cache"] {
+		if _, ok := cacheTTL[key]; !ok {
+			complete.Log("error: %s: unknown cache %q", source, key)
+			continue
+		}
+		ttl, err := time.ParseDuration(value)
+		if err != nil {
+			complete.Log("error: %s: %v", source, err)
+			continue
+		}
+		cacheTTL[key] = ttl
+	}
+	for key, value := range ini["predictors"] {
+		if !containsString(predictorNames, key) {
+			complete.Log("error: %s: unknown predictor %q", source, key)
+			continue
+		}
+		enabled, err := strconv.ParseBool(value)
+		if err != nil {
+			complete.Log("error: %s: %v", source, err)
+			continue
+		}
+		disabledPredictors[key] = !enabled
+	}
+}
+
+// envSettings are the environment variables that set connConfig, with the
+// names of their settings.
+var envSettings = []struct {
+	env  string
+	name string
+}{
+	{"COMPLETE_FACTOM_CLI_WALLET_SERVER", "w"},
+	{"COMPLETE_FACTOM_CLI_WALLET_TLS", "wallettls"},
+	{"COMPLETE_FACTOM_CLI_WALLET_CERT", "walletcert"},
+	{"COMPLETE_FACTOM_CLI_WALLET_USER", "walletuser"},
+	{"COMPLETE_FACTOM_CLI_WALLET_PASSWORD", "walletpassword"},
+	{"COMPLETE_FACTOM_CLI_WALLET_TIMEOUT", "wallettimeout"},
+	{"COMPLETE_FACTOM_CLI_FACTOMD_SERVER", "s"},
+	{"COMPLETE_FACTOM_CLI_FACTOMD_TLS", "factomdtls"},
+	{"COMPLETE_FACTOM_CLI_FACTOMD_CERT", "factomdcert"},
+	{"COMPLETE_FACTOM_CLI_FACTOMD_USER", "factomduser"},
+	{"COMPLETE_FACTOM_CLI_FACTOMD_PASSWORD", "factomdpassword"},
+	{"COMPLETE_FACTOM_CLI_FACTOMD_TIMEOUT", "factomdtimeout"},
+}
+
+// readEnv overrides cfg with the environment variables in envSettings that
+// are set and not empty.
+func (cfg *connConfig) readEnv() {
+	for _, s := range envSettings {
+		value := os.Getenv(s.env)
+		if len(value) == 0 {
+			continue
+		}
+		if err := cfg.set(s.name, value); err != nil {
+			complete.Log("error: %s: %v", s.env, err)
+			continue
+		}
+		cfg.setSource(s.name, "env "+s.env)
+	}
+}
+
+// readConfigFile returns the sections of the file name, or false if it
+// does not exist or can not be read.
+func readConfigFile(name string) (iniFile, bool) {
+	ini, err := readINI(name)
+	if err != nil {
+		if !os.IsNotExist(err) {
+			complete.Log("error: %v", err)
+		}
+		return nil, false
+	}
+	return ini, true
+}
+
+// setConfig overrides cfg with the settings of the sections of ini that keys
+// maps to settings of connConfig. Unless strict, unknown keys are ignored.
+// Empty values are always ignored.
+func (cfg *connConfig) setConfig(ini iniFile,
+	keys map[string]map[string]string, source string, strict bool) {
+	for section, settings := range keys {
+		for key, value := range ini[section] {
+			name, ok := settings[key]
+			if !ok {
+				if strict {
+					complete.Log("error: %s: unknown key %q in [%s]",
+						source, key, section)
+				}
+				continue
+			}
+			if len(value) == 0 {
+				continue
+			}
+			if err := cfg.set(name, value); err != nil {
+				complete.Log("error: %s: %v", source, err)
+				continue
+			}
+			cfg.setSource(name, source)
+		}
+	}
+}
+
+// set sets the setting name of cfg, which is the name of a factom-cli flag
+// or wallettimeout or factomdtimeout, from value.
+func (cfg *connConfig) set(name, value string) error {
+	var err error
+	switch name {
+	case "w":
+		cfg.WalletServer = value
+	case "wallettls":
+		err = setBool(&cfg.WalletTLS, value)
+	case "walletcert":
+		cfg.WalletCert = value
+	case "walletuser":
+		cfg.WalletUser = value
+	case "walletpassword":
+		cfg.WalletPassword = value
+	case "wallettimeout":
+		err = setDuration(&cfg.WalletTimeout, value)
+	case "s":
+		cfg.FactomdServer = value
+	case "factomdtls":
+		err = setBool(&cfg.FactomdTLS, value)
+	case "factomdcert":
+		cfg.FactomdCert = value
+	case "factomduser":
+		cfg.FactomdUser = value
+	case "factomdpassword":
+		cfg.FactomdPassword = value
+	case "factomdtimeout":
+		err = setDuration(&cfg.FactomdTimeout, value)
+	default:
+		return fmt.Errorf("unknown setting %q", name)
+	}
+	if err != nil {
+		return fmt.Errorf("%s: %v", name, err)
+	}
+	return nil
+}
+
+// setBool sets b from value, or leaves it alone if value is invalid.
+func setBool(b *bool, value string) error {
+	v, err := strconv.ParseBool(value)
+	if err != nil {
+		return err
+	}
+	*b = v
+	return nil
+}
+
+// setDuration sets d from value, or leaves it alone if value is invalid.
+func setDuration(d *time.Duration, value string) error {
+	v, err := time.ParseDuration(value)
+	if err != nil {
+		return err
+	}
+	*d = v
+	return nil
+}
+
+// iniFile holds the values of an INI file by lower case section and key.
+type iniFile map[string]map[string]string
+
+// readINI parses the INI file name in the format of factomd.conf: sections
+// in brackets, "key = value" lines, optionally double quoted values, and
+// comments starting with ; or #. Sections and keys are case insensitive.
+func readINI(name string) (iniFile, error) {
+	f, err := os.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	ini := make(iniFile)
+	var section string
+	scanner := bufio.NewScanner(f)
+	for n := 1; scanner.Scan(); n++ {
+		line := strings.TrimSpace(scanner.Text())
+		if len(line) == 0 || line[0] == ';' || line[0] == '#' {
+			continue
+		}
+		if line[0] == '[' {
+			end := strings.IndexByte(line, ']')
+			if end < 0 {
+				return nil, fmt.Errorf("%s:%d: unterminated section",
+					name, n)
+			}
+			section = strings.ToLower(strings.TrimSpace(line[1:end]))
+			if ini[section] == nil {
+				ini[section] = make(map[string]string)
+			}
+			continue
+		}
+		eq := strings.IndexByte(line, '=')
+		if eq < 0 || len(section) == 0 {
+			return nil, fmt.Errorf("%s:%d: expected key = value in a section",
+				name, n)
+		}
+		key := strings.ToLower(strings.TrimSpace(line[:eq]))
+		value, err := iniValue(strings.TrimSpace(line[eq+1:]))
+		if err != nil {
+			return nil, fmt.Errorf("%s:%d: %v", name, n, err)
+		}
+		ini[section][key] = value
+	}
+	return ini, scanner.Err()
+}
+
+// iniValue returns the value of a key, without quotes or trailing comment.
+func iniValue(s string) (string, error) {
+	if !strings.HasPrefix(s, `"`) {
+		if end := strings.IndexAny(s, ";#"); end >= 0 {
+			s = strings.TrimSpace(s[:end])
+		}
+		return s, nil
+	}
+	for i := 1; i < len(s); i++ {
+		switch s[i] {
+		case '\\':
+			i++
+		case '"':
+			return strconv.Unquote(s[:i+1])
+		}
+	}
+	return "", fmt.Errorf("unterminated quoted value")
+}
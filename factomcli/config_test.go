@@ -0,0 +1,165 @@
+package factomcli
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// TestConfig checks that factomd.conf, the configuration file and the flags
+// override each other in that order.
+func TestConfig(t *testing.T) {
+	f := newFixture(t)
+	defer f.close()
+	defer func(ttl time.Duration) {
+		cacheTTL[cacheTmpTxs] = ttl
+		disabledPredictors = make(map[string]bool)
+	}(cacheTTL[cacheTmpTxs])
+	os.Setenv("HOME", f.home)
+
+	for _, dir := range []string{filepath.Dir(factomdConfigPath()),
+		dataDir()} {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	writeTestFile(t, factomdConfigPath(), "; factomd.conf\n"+
+		"[app]\n"+
+		"FactomdRpcUser = \"factomd\"\n"+
+		"Network = MAIN\n"+
+		"[Walletd]\n"+
+		"WalletRpcUser = \"\"\n"+
+		"WalletTlsEnable = false\n"+
+		"WalletTlsPublicCert = \"/wallet.cert\"\n"+
+		"WalletdLocation = \""+f.wallet.addr()+"\"\n"+
+		"FactomdLocation = localhost:1\n")
+	writeTestFile(t, configPath(), "[factomd]\n"+
+		"server = "+f.factomd.addr()+" # the fake factomd\n"+
+		"timeout = 2s\n"+
+		"[walletd]\n"+
+		"user = wallet\n"+
+		"[cache]\n"+
+		"tmptxs = 1m\n"+
+		"[predictors]\n"+
+		"balances = false\n")
+
+	cfg := configureConnection([]string{"-walletuser", "me", "balance"})
+	factomdConfig, config := "config "+factomdConfigPath(), "config "+
+		configPath()
+	settings := []struct {
+		name   string
+		got    interface{}
+		want   interface{}
+		source string
+	}{
+		{"w", cfg.WalletServer, f.wallet.addr(), factomdConfig},
+		{"wallettls", cfg.WalletTLS, false, factomdConfig},
+		{"walletcert", cfg.WalletCert, "/wallet.cert", factomdConfig},
+		{"walletuser", cfg.WalletUser, "me", "flag -walletuser"},
+		{"wallettimeout", cfg.WalletTimeout, time.Second, "default"},
+		{"s", cfg.FactomdServer, f.factomd.addr(), config},
+		{"factomduser", cfg.FactomdUser, "factomd", factomdConfig},
+		{"factomdtimeout", cfg.FactomdTimeout, 2 * time.Second, config},
+	}
+	for _, s := range settings {
+		if s.got != s.want || cfg.source(s.name) != s.source {
+			t.Errorf("%s: got %v from %q, want %v from %q", s.name, s.got,
+				cfg.source(s.name), s.want, s.source)
+		}
+	}
+	if cacheTTL[cacheTmpTxs] != time.Minute {
+		t.Errorf("tmptxs TTL: got %v, want 1m", cacheTTL[cacheTmpTxs])
+	}
+
+	// Without balances, unfunded addresses are new inputs too.
+	line := "factom-cli addtxinput empty "
+	assertCandidates(t, line, f.completeProgram("bash", line),
+		[]string{fakeFA1, fakeFA2})
+	if n := f.factomd.called("factoid-balance"); n != 0 {
+		t.Errorf("factoid-balance called %d times", n)
+	}
+}
+
+func TestReadINI(t *testing.T) {
+	f := newFixture(t)
+	defer f.close()
+	name := filepath.Join(f.home, "test.conf")
+	writeTestFile(t, name, "# comment\n"+
+		"[Section]\n"+
+		"  Plain = a value ; comment\n"+
+		"quoted = \"a \\\"quoted\\\" ; value\" ; comment\n"+
+		"empty =\n")
+	ini, err := readINI(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := iniFile{"section": {
+		"plain":  "a value",
+		"quoted": `a "quoted" ; value`,
+		"empty":  "",
+	}}
+	if !reflect.DeepEqual(ini, want) {
+		t.Errorf("got %q, want %q", ini, want)
+	}
+
+	for _, content := range []string{
+		"key = value\n",
+		"[section\n",
+		"[section]\nkey\n",
+		"[section]\nkey = \"value\n",
+	} {
+		writeTestFile(t, name, content)
+		if _, err := readINI(name); err == nil {
+			t.Errorf("%q: no error", content)
+		}
+	}
+}
+
+// TestEnv checks that the environment overrides the configuration file and
+// that the flags override the environment.
+func TestEnv(t *testing.T) {
+	f := newFixture(t)
+	defer f.close()
+	os.Setenv("HOME", f.home)
+	if err := os.MkdirAll(dataDir(), 0755); err != nil {
+		t.Fatal(err)
+	}
+	writeTestFile(t, configPath(), "[factomd]\n"+
+		"server = localhost:1\n"+
+		"timeout = 2s\n")
+	env := map[string]string{
+		"COMPLETE_FACTOM_CLI_FACTOMD_SERVER": f.factomd.addr(),
+		"COMPLETE_FACTOM_CLI_WALLET_USER":    "env",
+		"COMPLETE_FACTOM_CLI_WALLET_TLS":     "true",
+		"COMPLETE_FACTOM_CLI_WALLET_TIMEOUT": "forever",
+	}
+	for name, value := range env {
+		defer os.Setenv(name, os.Getenv(name))
+		os.Setenv(name, value)
+	}
+
+	cfg := configureConnection([]string{"-walletuser", "me",
+		"-wallettls=false"})
+	settings := []struct {
+		name   string
+		got    interface{}
+		want   interface{}
+		source string
+	}{
+		{"s", cfg.FactomdServer, f.factomd.addr(),
+			"env COMPLETE_FACTOM_CLI_FACTOMD_SERVER"},
+		{"factomdtimeout", cfg.FactomdTimeout, 2 * time.Second,
+			"config " + configPath()},
+		{"walletuser", cfg.WalletUser, "me", "flag -walletuser"},
+		{"wallettls", cfg.WalletTLS, false, "flag -wallettls"},
+		{"wallettimeout", cfg.WalletTimeout, time.Second, "default"},
+	}
+	for _, s := range settings {
+		if s.got != s.want || cfg.source(s.name) != s.source {
+			t.Errorf("%s: got %v from %q, want %v from %q", s.name, s.got,
+				cfg.source(s.name), s.want, s.source)
+		}
+	}
+}
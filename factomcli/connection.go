@@ -0,0 +1,170 @@
+package factomcli
+
+import (
+	"flag"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/AdamSLevy/factom"
+	"github.com/posener/complete"
+)
+
+// connConfig holds the factom-cli options that determine how to connect to
+// factom-walletd and factomd.
+type connConfig struct {
+	WalletServer   string
+	WalletTLS      bool
+	WalletCert     string
+	WalletUser     string
+	WalletPassword string
+	WalletTimeout  time.Duration
+
+	FactomdServer   string
+	FactomdTLS      bool
+	FactomdCert     string
+	FactomdUser     string
+	FactomdPassword string
+	FactomdTimeout  time.Duration
+
+	// sources tells where each setting other than its default came from,
+	// by the name of its factom-cli flag.
+	sources map[string]string
+}
+
+// source returns where the setting of the factom-cli flag name came from.
+func (cfg connConfig) source(name string) string {
+	if source, ok := cfg.sources[name]; ok {
+		return source
+	}
+	return "default"
+}
+
+// setSource records where the setting of the factom-cli flag name came
+// from.
+func (cfg *connConfig) setSource(name, source string) {
+	if cfg.sources == nil {
+		cfg.sources = make(map[string]string)
+	}
+	cfg.sources[name] = source
+}
+
+// defaultConnConfig returns the same defaults that factom-cli uses, with
+// timeouts short enough that completion never hangs.
+func defaultConnConfig() connConfig {
+	return connConfig{
+		WalletServer:  "localhost:8089",
+		WalletCert:    "~/.factom/walletAPIpub.cert",
+		WalletTimeout: 1 * time.Second,
+
+		FactomdServer:  "localhost:8088",
+		FactomdCert:    "~/.factom/m2/factomdAPIpub.cert",
+		FactomdTimeout: 1 * time.Second,
+	}
+}
+
+// parseFlags overrides cfg with any factom-cli connection flags in args and
+// returns the remaining arguments. Parsing stops at the first non-flag
+// argument, which is the factom-cli sub command, just like factom-cli itself.
+func (cfg *connConfig) parseFlags(args []string) []string {
+	// Using flag.FlagSet allows us to parse a custom array of flags
+	// instead of this programs args.
+	flags := flag.NewFlagSet("", flag.ContinueOnError)
+	// flags.Parse will print warnings if it comes across an unrecognized
+	// flag. We don't want this so we discard all output.
+	flags.SetOutput(ioutil.Discard)
+
+	flags.StringVar(&cfg.WalletServer, "w", cfg.WalletServer, "")
+	flags.BoolVar(&cfg.WalletTLS, "wallettls", cfg.WalletTLS, "")
+	flags.StringVar(&cfg.WalletCert, "walletcert", cfg.WalletCert, "")
+	flags.StringVar(&cfg.WalletUser, "walletuser", cfg.WalletUser, "")
+	flags.StringVar(&cfg.WalletPassword, "walletpassword",
+		cfg.WalletPassword, "")
+
+	flags.StringVar(&cfg.FactomdServer, "s", cfg.FactomdServer, "")
+	flags.BoolVar(&cfg.FactomdTLS, "factomdtls", cfg.FactomdTLS, "")
+	flags.StringVar(&cfg.FactomdCert, "factomdcert", cfg.FactomdCert, "")
+	flags.StringVar(&cfg.FactomdUser, "factomduser", cfg.FactomdUser, "")
+	flags.StringVar(&cfg.FactomdPassword, "factomdpassword",
+		cfg.FactomdPassword, "")
+
+	if err := flags.Parse(args); err != nil {
+		complete.Log("error: %v", err)
+	}
+	flags.Visit(func(f *flag.Flag) {
+		cfg.setSource(f.Name, "flag -"+f.Name)
+	})
+	return flags.Args()
+}
+
+// apply sets factom.RpcConfig from cfg.
+func (cfg connConfig) apply() {
+	factom.SetWalletServer(cfg.WalletServer)
+	factom.SetWalletEncryption(cfg.WalletTLS, expandHome(cfg.WalletCert))
+	factom.SetWalletRpcConfig(cfg.WalletUser, cfg.WalletPassword)
+	// We need factom-walletd and factomd to timeout or the CLI
+	// completion will hang and never return. This is the whole reason we
+	// use AdamSLevy's fork of factom.
+	factom.SetWalletTimeout(cfg.WalletTimeout)
+
+	factom.SetFactomdServer(cfg.FactomdServer)
+	factom.SetFactomdEncryption(cfg.FactomdTLS, expandHome(cfg.FactomdCert))
+	factom.SetFactomdRpcConfig(cfg.FactomdUser, cfg.FactomdPassword)
+	factom.SetFactomdTimeout(cfg.FactomdTimeout)
+}
+
+var (
+	// connConfigured is set once parseConnectionFlags has configured
+	// factom.RpcConfig.
+	connConfigured bool
+	// connArgs holds the factom-cli flags from COMP_LINE that were used to
+	// configure the connection.
+	connArgs []string
+)
+
+// parseConnectionFlags parses any previously specified factom-cli options
+// required for connecting to factom-walletd and factomd and applies them to
+// factom.RpcConfig. It must be called before any request to factom-walletd or
+// factomd.
+func parseConnectionFlags() {
+	if connConfigured {
+		// We already parsed the flags.
+		return
+	}
+	connConfigured = true
+
+	// The current command line being typed is stored in the environment
+	// variable COMP_LINE. We split it into words like the shell does and
+	// discard the first because it is the program name `factom-cli`.
+	words := splitWords(completionLine())
+	args := make([]string, len(words)-1)
+	for i, w := range words[1:] {
+		args[i] = w.text
+	}
+	configureConnection(args)
+}
+
+// configureConnection applies the configuration files, the environment and
+// then the factom-cli connection flags at the start of args to
+// factom.RpcConfig and returns the resulting connConfig.
+func configureConnection(args []string) connConfig {
+	cfg := defaultConnConfig()
+	cfg.readConfigFiles()
+	cfg.readEnv()
+	remaining := cfg.parseFlags(args)
+	connArgs = args[:len(args)-len(remaining)]
+	cfg.apply()
+	return cfg
+}
+
+// expandHome replaces a leading ~ in path with the user's home directory.
+func expandHome(path string) string {
+	if path == "~" {
+		return homeDir()
+	}
+	if strings.HasPrefix(path, "~/") {
+		return filepath.Join(homeDir(), path[2:])
+	}
+	return path
+}
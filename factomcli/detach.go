@@ -0,0 +1,15 @@
+//go:build !windows
+// +build !windows
+
+package factomcli
+
+import (
+	"os/exec"
+	"syscall"
+)
+
+// detach starts cmd in its own session so that it outlives this process and
+// does not receive signals sent to the shell's process group.
+func detach(cmd *exec.Cmd) {
+	cmd.SysProcAttr = &syscall.SysProcAttr{Setsid: true}
+}
@@ -0,0 +1,13 @@
+package factomcli
+
+import (
+	"os/exec"
+	"syscall"
+)
+
+// detach starts cmd in a new process group so that it outlives this process.
+func detach(cmd *exec.Cmd) {
+	cmd.SysProcAttr = &syscall.SysProcAttr{
+		CreationFlags: syscall.CREATE_NEW_PROCESS_GROUP,
+	}
+}
@@ -0,0 +1,398 @@
+package factomcli
+
+import (
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/AdamSLevy/factom"
+)
+
+// doctor diagnoses why completion may return nothing. Each check writes a
+// line for each of its findings.
+type doctor struct {
+	w      io.Writer
+	failed bool
+}
+
+// runDoctor writes a diagnosis of the completion of the programs to w: the
+// shells it is installed in, the configuration files, the connections to
+// factom-walletd and factomd as configured by those files and the
+// factom-cli flags in args, the state of the cache and whether the
+// predictors get their data within their budget. It returns false if any
+// check failed.
+func runDoctor(w io.Writer, programs []string, args []string) bool {
+	d := &doctor{w: w}
+	d.checkShells(programs)
+
+	d.checkConfig()
+	connConfigured = true
+	cfg := configureConnection(args)
+	d.checkWallet(cfg)
+	d.checkFactomd(cfg)
+	d.checkCache()
+	d.checkPredictors(cfg)
+	return !d.failed
+}
+
+func (d *doctor) section(title string) {
+	fmt.Fprintf(d.w, "%s\n", title)
+}
+
+func (d *doctor) item(name, format string, args ...interface{}) {
+	fmt.Fprintf(d.w, "  %-20s %s\n", name+":", fmt.Sprintf(format, args...))
+}
+
+func (d *doctor) fail(name string, err error) {
+	d.failed = true
+	d.item(name, "FAILED: %v", err)
+}
+
+// checkShells reports the programs whose completion is installed in each
+// shell.
+func (d *doctor) checkShells(programs []string) {
+	d.section("Shells")
+	configured := make(map[string]shell)
+	for _, sh := range installedShells(homeDir()) {
+		configured[sh.name()] = sh
+	}
+	for _, name := range []string{"bash", "zsh", "fish"} {
+		sh, ok := configured[name]
+		if !ok {
+			d.item(name, "not configured")
+			continue
+		}
+		installed := sh.installed(programs)
+		if len(installed) == 0 {
+			d.item(name, "not installed, run complete-factom-cli -install")
+			continue
+		}
+		d.item(name, "installed for %s", strings.Join(installed, ", "))
+	}
+}
+
+// checkConfig reports whether the configuration files exist and parse.
+func (d *doctor) checkConfig() {
+	d.section("Configuration")
+	for _, name := range []string{factomdConfigPath(), configPath()} {
+		_, err := readINI(name)
+		switch {
+		case os.IsNotExist(err):
+			d.item(filepath.Base(name), "%s not found", name)
+		case err != nil:
+			d.fail(filepath.Base(name), err)
+		default:
+			d.item(filepath.Base(name), "%s", name)
+		}
+	}
+}
+
+// checkWallet reports the connection to factom-walletd.
+func (d *doctor) checkWallet(cfg connConfig) {
+	d.section("factom-walletd")
+	d.item("server", "%s (%s)", cfg.WalletServer, cfg.source("w"))
+	d.checkTLS(cfg.WalletTLS, cfg.source("wallettls"), cfg.WalletCert,
+		cfg.source("walletcert"))
+	d.checkUser(cfg.WalletUser, cfg.source("walletuser"),
+		cfg.WalletPassword, cfg.source("walletpassword"))
+	d.item("timeout", "%v (%s)", cfg.WalletTimeout,
+		cfg.source("wallettimeout"))
+
+	start := time.Now()
+	height, err := rpcClient{}.GetWalletHeight()
+	if err != nil {
+		d.fail("latency", err)
+		return
+	}
+	d.item("latency", "%v (wallet height %d)", roundDuration(time.Since(start)),
+		height)
+}
+
+// checkFactomd reports the connection to factomd.
+func (d *doctor) checkFactomd(cfg connConfig) {
+	d.section("factomd")
+	d.item("server", "%s (%s)", cfg.FactomdServer, cfg.source("s"))
+	d.checkTLS(cfg.FactomdTLS, cfg.source("factomdtls"), cfg.FactomdCert,
+		cfg.source("factomdcert"))
+	d.checkUser(cfg.FactomdUser, cfg.source("factomduser"),
+		cfg.FactomdPassword, cfg.source("factomdpassword"))
+	d.item("timeout", "%v (%s)", cfg.FactomdTimeout,
+		cfg.source("factomdtimeout"))
+
+	start := time.Now()
+	heights, err := rpcClient{}.GetHeights()
+	if err != nil {
+		d.fail("latency", err)
+		return
+	}
+	d.item("latency", "%v (directory block height %d)",
+		roundDuration(time.Since(start)), heights.DirectoryBlockHeight)
+}
+
+// checkTLS reports whether TLS is enabled and, if so, whether the
+// certificate is valid.
+func (d *doctor) checkTLS(enabled bool, source, cert, certSource string) {
+	if !enabled {
+		d.item("TLS", "off (%s)", source)
+		return
+	}
+	d.item("TLS", "on (%s)", source)
+	path := expandHome(cert)
+	c, err := readCertificate(path)
+	if err != nil {
+		d.fail("certificate", err)
+		return
+	}
+	const date = "2006-01-02"
+	switch now := time.Now(); {
+	case now.After(c.NotAfter):
+		d.fail("certificate", fmt.Errorf("%s (%s) expired on %s", path,
+			certSource, c.NotAfter.Format(date)))
+	case now.Before(c.NotBefore):
+		d.fail("certificate", fmt.Errorf("%s (%s) is not valid before %s",
+			path, certSource, c.NotBefore.Format(date)))
+	default:
+		d.item("certificate", "%s (%s) valid until %s", path, certSource,
+			c.NotAfter.Format(date))
+	}
+}
+
+// checkUser reports the RPC credentials. The password is never shown.
+func (d *doctor) checkUser(user, userSource, password,
+	passwordSource string) {
+	if len(user) == 0 && len(password) == 0 {
+		d.item("RPC user", "none")
+		return
+	}
+	d.item("RPC user", "%s (%s)", user, userSource)
+	if len(password) > 0 {
+		d.item("RPC password", "set (%s)", passwordSource)
+	} else {
+		d.item("RPC password", "not set")
+	}
+}
+
+// readCertificate returns the first certificate in the PEM file name.
+func readCertificate(name string) (*x509.Certificate, error) {
+	data, err := ioutil.ReadFile(name)
+	if err != nil {
+		return nil, err
+	}
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, fmt.Errorf("%s: no PEM certificate", name)
+	}
+	return x509.ParseCertificate(block.Bytes)
+}
+
+// checkCache reports the age of the cached data of each kind.
+func (d *doctor) checkCache() {
+	d.section("Cache " + cacheDir())
+	var kinds []string
+	for kind := range cacheTTL {
+		kinds = append(kinds, kind)
+	}
+	sort.Strings(kinds)
+	for _, kind := range kinds {
+		entry, err := readCacheEntry(kind)
+		if os.IsNotExist(err) {
+			d.item(kind, "empty")
+			continue
+		}
+		if err != nil {
+			d.fail(kind, err)
+			continue
+		}
+		age := time.Since(entry.Updated)
+		state := "fresh"
+		if age > cacheTTL[kind] {
+			state = "stale"
+		}
+		d.item(kind, "%s, updated %v ago (TTL %v)", state,
+			age.Round(time.Second), cacheTTL[kind])
+	}
+}
+
+// doctorFetches are the data that the predictors fetch. Each request that a
+// fetch makes adds the timeout of its server to the budget.
+var doctorFetches = []struct {
+	name string
+	kind string
+}{
+	{"addresses", cacheAddresses},
+	{"tmp transactions", cacheTmpTxs},
+	{"transaction IDs", cacheTxIDs},
+	{"pending entries", cachePendingEntries},
+	{"heights", cacheHeights},
+	{"directory blocks", cacheDBlocks},
+	{"entry blocks", cacheEBlocks},
+}
+
+// checkPredictors reports whether the data of each predictor is fetched
+// within its budget. The cache is bypassed.
+func (d *doctor) checkPredictors(cfg connConfig) {
+	d.section("Predictors")
+	var addresses addressesCache
+	for _, fetch := range doctorFetches {
+		if !predictorEnabled(fetch.kind) {
+			d.item(fetch.name, "disabled")
+			continue
+		}
+		c := &countingClient{Client: rpcClient{}}
+		start := time.Now()
+		v, err := cacheFetch[fetch.kind](c)
+		elapsed := roundDuration(time.Since(start))
+		if err != nil {
+			d.fail(fetch.name, err)
+			continue
+		}
+		budget := time.Duration(c.wallet)*cfg.WalletTimeout +
+			time.Duration(c.factomd)*cfg.FactomdTimeout
+		if a, ok := v.(addressesCache); ok {
+			addresses = a
+		}
+		d.checkBudget(fetch.name, countItems(v), elapsed, budget)
+	}
+
+	total := len(addresses.FCT) + len(addresses.EC)
+	if !predictorEnabled("balances") {
+		d.item("balances", "disabled")
+		return
+	}
+	if total == 0 {
+		d.item("balances", "no addresses")
+		return
+	}
+	start := time.Now()
+	balances := rpcPredictors.fetchBalances(addresses.FCT, addresses.EC)
+	elapsed := roundDuration(time.Since(start))
+	if len(balances) < total {
+		d.fail("balances", fmt.Errorf("%d of %d within %v", len(balances),
+			total, balanceTimeout))
+		return
+	}
+	d.checkBudget("balances", total, elapsed, balanceTimeout)
+}
+
+// countingClient counts the requests that a Client makes to factom-walletd
+// and to factomd.
+type countingClient struct {
+	Client
+	wallet, factomd int
+}
+
+func (c *countingClient) FetchAddresses() ([]*factom.FactoidAddress,
+	[]*factom.ECAddress, error) {
+	c.wallet++
+	return c.Client.FetchAddresses()
+}
+
+func (c *countingClient) ListTransactionsTmp() ([]*factom.Transaction, error) {
+	c.wallet++
+	return c.Client.ListTransactionsTmp()
+}
+
+func (c *countingClient) ListTransactionsAll() ([]*factom.Transaction, error) {
+	c.wallet++
+	return c.Client.ListTransactionsAll()
+}
+
+func (c *countingClient) ListTransactionsAddress(address string) (
+	[]*factom.Transaction, error) {
+	c.wallet++
+	return c.Client.ListTransactionsAddress(address)
+}
+
+func (c *countingClient) GetWalletHeight() (uint32, error) {
+	c.wallet++
+	return c.Client.GetWalletHeight()
+}
+
+func (c *countingClient) GetFactoidBalance(address string) (int64, error) {
+	c.factomd++
+	return c.Client.GetFactoidBalance(address)
+}
+
+func (c *countingClient) GetECBalance(address string) (int64, error) {
+	c.factomd++
+	return c.Client.GetECBalance(address)
+}
+
+func (c *countingClient) GetRate() (uint64, error) {
+	c.factomd++
+	return c.Client.GetRate()
+}
+
+func (c *countingClient) GetHeights() (*factom.HeightsResponse, error) {
+	c.factomd++
+	return c.Client.GetHeights()
+}
+
+func (c *countingClient) GetPendingEntries() (string, error) {
+	c.factomd++
+	return c.Client.GetPendingEntries()
+}
+
+func (c *countingClient) GetPendingTransactions() (string, error) {
+	c.factomd++
+	return c.Client.GetPendingTransactions()
+}
+
+func (c *countingClient) GetDBlockHead() (string, error) {
+	c.factomd++
+	return c.Client.GetDBlockHead()
+}
+
+func (c *countingClient) GetDBlock(keymr string) (*factom.DBlock, error) {
+	c.factomd++
+	return c.Client.GetDBlock(keymr)
+}
+
+func (c *countingClient) GetChainHead(chainID string) (string, error) {
+	c.factomd++
+	return c.Client.GetChainHead(chainID)
+}
+
+func (c *countingClient) GetEBlock(keymr string) (*factom.EBlock, error) {
+	c.factomd++
+	return c.Client.GetEBlock(keymr)
+}
+
+func (c *countingClient) GetFirstEntry(chainID string) (*factom.Entry, error) {
+	c.factomd++
+	return c.Client.GetFirstEntry(chainID)
+}
+
+// checkBudget reports the number of items fetched in elapsed time.
+func (d *doctor) checkBudget(name string, items int, elapsed,
+	budget time.Duration) {
+	if elapsed > budget {
+		d.fail(name, fmt.Errorf("%d items in %v, over the budget of %v",
+			items, elapsed, budget))
+		return
+	}
+	d.item(name, "%d items in %v (budget %v)", items, elapsed, budget)
+}
+
+// countItems returns the number of items in data fetched for the cache.
+func countItems(v interface{}) int {
+	if a, ok := v.(addressesCache); ok {
+		return len(a.FCT) + len(a.EC)
+	}
+	if rv := reflect.ValueOf(v); rv.Kind() == reflect.Slice {
+		return rv.Len()
+	}
+	return 1
+}
+
+func roundDuration(d time.Duration) time.Duration {
+	return d.Round(time.Millisecond)
+}
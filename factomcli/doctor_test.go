@@ -0,0 +1,123 @@
+package factomcli
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// TestDoctor checks the diagnosis of a completion that is installed in bash
+// and connects to the fake servers.
+func TestDoctor(t *testing.T) {
+	f := newFixture(t)
+	defer f.close()
+	os.Setenv("HOME", f.home)
+	writeTestFile(t, filepath.Join(f.home, ".bashrc"),
+		"complete -C /usr/bin/complete-factom-cli factom-cli\n")
+
+	var out bytes.Buffer
+	ok := runDoctor(&out, []string{"factom-cli", "factomd"}, []string{
+		"-w", f.wallet.addr(), "-s", f.factomd.addr(), "-walletuser", "me",
+		"-walletpassword", "secret", "balance"})
+	if !ok {
+		t.Errorf("doctor failed:\n%s", &out)
+	}
+	for _, want := range []string{
+		"  bash:                installed for factom-cli\n",
+		"  zsh:                 not configured\n",
+		"  config:              " + configPath() + " not found\n",
+		"  server:              " + f.wallet.addr() + " (flag -w)\n",
+		"  server:              " + f.factomd.addr() + " (flag -s)\n",
+		"  TLS:                 off (default)\n",
+		"  RPC user:            me (flag -walletuser)\n",
+		"  RPC password:        set (flag -walletpassword)\n",
+		"  addresses:           empty\n",
+		"  addresses:           4 items in ",
+		"  tmp transactions:    3 items in ",
+		// The head and both directory blocks are requested.
+		"  directory blocks:    2 items in ",
+		" (budget 3s)\n  entry blocks:",
+		"  balances:            4 items in ",
+	} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("missing %q in:\n%s", want, &out)
+		}
+	}
+	if strings.Contains(out.String(), "secret") {
+		t.Errorf("the password is shown:\n%s", &out)
+	}
+
+	f.wallet.Close()
+	out.Reset()
+	ok = runDoctor(&out, []string{"factom-cli"}, []string{
+		"-w", f.wallet.addr(), "-s", f.factomd.addr()})
+	if ok || !strings.Contains(out.String(), "  latency:             FAILED: ") {
+		t.Errorf("doctor did not fail without factom-walletd:\n%s", &out)
+	}
+}
+
+// TestDoctorCertificates checks the validity of TLS certificates.
+func TestDoctorCertificates(t *testing.T) {
+	f := newFixture(t)
+	defer f.close()
+
+	now := time.Now()
+	tests := []struct {
+		notBefore, notAfter time.Time
+		want                string
+	}{
+		{now.Add(-time.Hour), now.Add(time.Hour), " valid until "},
+		{now.Add(-2 * time.Hour), now.Add(-time.Hour), "FAILED: "},
+		{now.Add(time.Hour), now.Add(2 * time.Hour), "FAILED: "},
+	}
+	for i, test := range tests {
+		cert := filepath.Join(f.home, "cert.pem")
+		writeTestFile(t, cert, testCertificate(t, test.notBefore,
+			test.notAfter))
+		var out bytes.Buffer
+		d := &doctor{w: &out}
+		d.checkTLS(true, "flag -wallettls", cert, "flag -walletcert")
+		if !strings.Contains(out.String(), test.want) {
+			t.Errorf("%d: missing %q in:\n%s", i, test.want, &out)
+		}
+	}
+
+	var out bytes.Buffer
+	d := &doctor{w: &out}
+	d.checkTLS(true, "flag -wallettls", filepath.Join(f.home, "missing"),
+		"default")
+	if !d.failed {
+		t.Errorf("no failure for a missing certificate:\n%s", &out)
+	}
+}
+
+// testCertificate returns a self-signed PEM certificate.
+func testCertificate(t *testing.T, notBefore, notAfter time.Time) string {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "localhost"},
+		NotBefore:    notBefore,
+		NotAfter:     notAfter,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template,
+		&key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE",
+		Bytes: der}))
+}
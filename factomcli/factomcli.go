@@ -0,0 +1,91 @@
+// Package factomcli implements shell completion for factom-cli, factomd and
+// factom-walletd.
+//
+// New returns the completion command tree of factom-cli. Its predictors are
+// exported so that they can be reused in the completion of other programs
+// that take Factom addresses, transactions, chains or blocks as arguments.
+package factomcli
+
+import (
+	"os"
+
+	"github.com/posener/complete"
+)
+
+// CLI is the completion of factom-cli. It also completes factomd and
+// factom-walletd.
+type CLI struct {
+	// Command is the completion command tree of factom-cli.
+	Command complete.Command
+
+	rules   commandRules
+	help    commandHelp
+	version string
+	client  Client
+}
+
+// Option configures a CLI.
+type Option func(*CLI)
+
+// WithClient makes the predictors of the CLI query c instead of the
+// factom-walletd and factomd servers given by the factom-cli flags on the
+// command line. The exported predictors always query the servers.
+func WithClient(c Client) Option {
+	return func(cli *CLI) {
+		cli.client = c
+	}
+}
+
+// WithVersion selects the command tree of the given version of factom-cli
+// instead of that of the detected version.
+func WithVersion(version string) Option {
+	return func(cli *CLI) {
+		cli.version = version
+	}
+}
+
+// New returns the completion of factom-cli configured by opts. Unless
+// WithVersion is given, the version of factom-cli is detected.
+func New(opts ...Option) *CLI {
+	cli := &CLI{client: rpcClient{}}
+	for _, opt := range opts {
+		opt(cli)
+	}
+	if len(cli.version) == 0 {
+		cli.version = factomCLIVersion()
+	}
+	return newCLI(cli.version, cli.client)
+}
+
+// Run completes the command line in COMP_LINE and writes the candidates to
+// stdout, or runs the install flags in args if there is none. When started
+// to refresh the cache in the background, it does only that. Args are the
+// command line arguments without the program name.
+func (cli *CLI) Run(args []string) error {
+	if refreshCache() {
+		return nil
+	}
+	return run(cli.programs(), args, os.Stdout)
+}
+
+// programs returns the programs that cli completes. The first is completed
+// if the command line names none of them.
+func (cli *CLI) programs() []program {
+	factomd, factomdRules := newFactomdCommand()
+	walletd, walletdRules := newWalletdCommand()
+	return []program{
+		{"factom-cli", cli.Command, cli.rules, cli.help},
+		{"factomd", factomd, factomdRules,
+			newCommandHelp(selectSpecs(factomdSpecs, ""))},
+		{"factom-walletd", walletd, walletdRules,
+			newCommandHelp(selectSpecs(walletdSpecs, ""))},
+	}
+}
+
+// program is a program whose command line is completed.
+type program struct {
+	name    string
+	command complete.Command
+	rules   commandRules
+	help    commandHelp
+}
@@ -0,0 +1,287 @@
+package factomcli
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/AdamSLevy/factom"
+)
+
+// Addresses in the fake wallet.
+const (
+	fakeFA1 = "FA2ByUMfEYdYfgDWwe7oC8EAy1XHfVpMgPm6szbvzZMpSHHy1s1z"
+	fakeFs1 = "Fs1KwrPgWcH81QfcvPbYPA9jV2rv75E6QXBnAVSdB7e4EDnEAvfz"
+	fakeFA2 = "FA39JmyxUSwSy5VrJckEReokME84dKD16xpXSnLGiKEZfEg8m7N5"
+	fakeFs2 = "Fs1LPPvYgatLPtZguRAJFTMp9iwhZHqNTSP2THGEg6H8m84VtjfC"
+	fakeEC1 = "EC3LE8WFRfA5YxBMJg7hYZjEeAQLjFXRMnTYtK5EKie4jBGBsvWr"
+	fakeEs1 = "Es2S6fFDGNF6E9SGn4mmm5NVkmXXnSzFZDTFvSJcKPzVJVwMUw7g"
+	fakeEC2 = "EC2aV6UEb9Hto1P19TD7id9wAhrpmCfh84KVyK1ZTCheb8hoXRmQ"
+	fakeEs2 = "Es2SYCn5SLrJcdLLm6LXdNaaRTcKEfbXc8eWDE8DpNdZqQANZbag"
+)
+
+// Hashes of the fake blockchain. The chain fakeChainID has a single entry
+// block that holds its first entry and one more entry. One more entry is
+// pending.
+var (
+	fakeChainID      = strings.Repeat("cc", 32)
+	fakeDBlockHead   = strings.Repeat("d5", 32)
+	fakeDBlockPrev   = strings.Repeat("d4", 32)
+	fakeEBlock       = strings.Repeat("e5", 32)
+	fakeFirstEntry   = strings.Repeat("21", 32)
+	fakeEntry        = strings.Repeat("22", 32)
+	fakePendingEntry = strings.Repeat("11", 32)
+	fakePendingTxID  = strings.Repeat("b1", 32)
+	fakeOldTxID      = strings.Repeat("a1", 32)
+	fakeNewTxID      = strings.Repeat("a2", 32)
+)
+
+// fakeMethod returns the result of a JSON-RPC method called with params.
+type fakeMethod func(params json.RawMessage) (interface{}, *factom.JSONError)
+
+// fakeServer is an in-process fake of the JSON-RPC 2.0 API of
+// factom-walletd or factomd.
+type fakeServer struct {
+	*httptest.Server
+
+	mu      sync.Mutex
+	methods map[string]fakeMethod
+	delay   time.Duration
+	calls   map[string]int
+}
+
+func newFakeServer(methods map[string]fakeMethod) *fakeServer {
+	s := &fakeServer{methods: methods, calls: make(map[string]int)}
+	s.Server = httptest.NewServer(s)
+	return s
+}
+
+// addr returns the host:port of the server for the -w and -s flags.
+func (s *fakeServer) addr() string {
+	return strings.TrimPrefix(s.URL, "http://")
+}
+
+// handle sets the method, replacing any previous one.
+func (s *fakeServer) handle(method string, m fakeMethod) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.methods[method] = m
+}
+
+// fail makes the method return a JSON-RPC error.
+func (s *fakeServer) fail(method string) {
+	s.handle(method, func(json.RawMessage) (interface{}, *factom.JSONError) {
+		return nil, factom.NewJSONError(-32603, "Internal error", nil)
+	})
+}
+
+// setDelay delays all responses by d.
+func (s *fakeServer) setDelay(d time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.delay = d
+}
+
+// called returns how many times the method was called.
+func (s *fakeServer) called(method string) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.calls[method]
+}
+
+func (s *fakeServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		ID     interface{}     `json:"id"`
+		Method string          `json:"method"`
+		Params json.RawMessage `json:"params"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	s.mu.Lock()
+	s.calls[req.Method]++
+	method, delay := s.methods[req.Method], s.delay
+	s.mu.Unlock()
+	time.Sleep(delay)
+
+	resp := map[string]interface{}{"jsonrpc": "2.0", "id": req.ID}
+	if method == nil {
+		resp["error"] = factom.NewJSONError(-32601, "Method not found", nil)
+	} else if result, err := method(req.Params); err != nil {
+		resp["error"] = err
+	} else {
+		resp["result"] = result
+	}
+	json.NewEncoder(w).Encode(resp)
+}
+
+// result returns a fakeMethod that always returns v.
+func result(v interface{}) fakeMethod {
+	return func(json.RawMessage) (interface{}, *factom.JSONError) {
+		return v, nil
+	}
+}
+
+// byParam returns a fakeMethod that looks up its result in results by the
+// string parameter named param.
+func byParam(param string, results map[string]interface{}) fakeMethod {
+	return func(params json.RawMessage) (interface{}, *factom.JSONError) {
+		var p map[string]string
+		json.Unmarshal(params, &p)
+		if v, ok := results[p[param]]; ok {
+			return v, nil
+		}
+		return nil, factom.NewJSONError(-32009, "Missing Chain Head", nil)
+	}
+}
+
+type fakeIO struct {
+	address string
+	amount  uint64
+}
+
+// fakeTx returns a transaction as factom-walletd encodes it.
+func fakeTx(name, txid string, timestamp int64,
+	inputs, outputs, ecOutputs []fakeIO) map[string]interface{} {
+	encode := func(ios []fakeIO) ([]map[string]interface{}, uint64) {
+		var total uint64
+		encoded := []map[string]interface{}{}
+		for _, io := range ios {
+			encoded = append(encoded, map[string]interface{}{
+				"address": io.address, "amount": io.amount})
+			total += io.amount
+		}
+		return encoded, total
+	}
+	ins, totalIns := encode(inputs)
+	outs, totalOuts := encode(outputs)
+	ecs, totalECs := encode(ecOutputs)
+	return map[string]interface{}{
+		"name":           name,
+		"txid":           txid,
+		"signed":         len(txid) > 0,
+		"timestamp":      timestamp,
+		"inputs":         ins,
+		"outputs":        outs,
+		"ecoutputs":      ecs,
+		"totalinputs":    totalIns,
+		"totaloutputs":   totalOuts,
+		"totalecoutputs": totalECs,
+	}
+}
+
+// newFakeWallet returns a fake factom-walletd with two FCT and two EC
+// addresses and the tmp transactions "empty", "ready" and "signed".
+func newFakeWallet() *fakeServer {
+	var addresses []map[string]string
+	for _, pair := range [][2]string{{fakeFA1, fakeFs1}, {fakeFA2, fakeFs2},
+		{fakeEC1, fakeEs1}, {fakeEC2, fakeEs2}} {
+		addresses = append(addresses,
+			map[string]string{"public": pair[0], "secret": pair[1]})
+	}
+	spend := []fakeIO{{fakeFA1, 200000000}}
+	pay := []fakeIO{{fakeFA2, 100000000}}
+	tmpTxs := []interface{}{
+		fakeTx("empty", "", 1500000000, nil, nil, nil),
+		fakeTx("ready", "", 1500000000, spend, pay, nil),
+		fakeTx("signed", strings.Repeat("ab", 32), 1500000000,
+			spend, pay, nil),
+	}
+	txs := []interface{}{
+		fakeTx("", fakeOldTxID, 1533117600,
+			[]fakeIO{{fakeFA1, 300000000}}, pay, nil),
+		fakeTx("", fakeNewTxID, 1535796000, spend, pay, nil),
+	}
+	return newFakeServer(map[string]fakeMethod{
+		"all-addresses": result(map[string]interface{}{
+			"addresses": addresses}),
+		"tmp-transactions": result(map[string]interface{}{
+			"transactions": tmpTxs}),
+		"transactions": result(map[string]interface{}{
+			"transactions": txs}),
+		"get-height": result(map[string]interface{}{"height": 990}),
+	})
+}
+
+// newFakeFactomd returns a fake factomd that knows the balances of the
+// addresses of the fake wallet and the fake blockchain.
+func newFakeFactomd() *fakeServer {
+	balance := func(balances map[string]int64) fakeMethod {
+		return func(params json.RawMessage) (interface{}, *factom.JSONError) {
+			var p struct {
+				Address string `json:"address"`
+			}
+			json.Unmarshal(params, &p)
+			return map[string]int64{"balance": balances[p.Address]}, nil
+		}
+	}
+	hexNames := func(names ...string) []string {
+		var encoded []string
+		for _, name := range names {
+			encoded = append(encoded, hex.EncodeToString([]byte(name)))
+		}
+		return encoded
+	}
+	zero := factom.ZeroHash
+	return newFakeServer(map[string]fakeMethod{
+		"factoid-balance": balance(map[string]int64{
+			fakeFA1: 500000000, fakeFA2: 0}),
+		"entry-credit-balance": balance(map[string]int64{
+			fakeEC1: 1000, fakeEC2: 5}),
+		"entry-credit-rate": result(map[string]int{"rate": 1000}),
+		"heights": result(map[string]int{
+			"directoryblockheight": 1000,
+			"leaderheight":         1001,
+			"entryblockheight":     1000,
+			"entryheight":          1000,
+		}),
+		"pending-entries": result([]map[string]string{{
+			"EntryHash": fakePendingEntry,
+			"ChainID":   fakeChainID,
+			"Status":    "TransactionACK",
+		}}),
+		"pending-transactions": result([]map[string]interface{}{{
+			"TransactionID": fakePendingTxID,
+			"Status":        "TransactionACK",
+			"Inputs": []map[string]interface{}{{
+				"amount": 150000000, "address": fakeFA1}},
+		}}),
+		"directory-block-head": result(map[string]string{
+			"keymr": fakeDBlockHead}),
+		"directory-block": byParam("keymr", map[string]interface{}{
+			fakeDBlockHead: map[string]interface{}{"header": map[string]interface{}{
+				"prevblockkeymr": fakeDBlockPrev, "sequencenumber": 1000}},
+			fakeDBlockPrev: map[string]interface{}{"header": map[string]interface{}{
+				"prevblockkeymr": zero, "sequencenumber": 999}},
+		}),
+		"chain-head": byParam("chainid", map[string]interface{}{
+			fakeChainID: map[string]string{"chainhead": fakeEBlock},
+		}),
+		"entry-block": byParam("keymr", map[string]interface{}{
+			fakeEBlock: map[string]interface{}{
+				"header": map[string]interface{}{
+					"blocksequencenumber": 7,
+					"chainid":             fakeChainID,
+					"prevkeymr":           zero,
+				},
+				"entrylist": []map[string]string{
+					{"entryhash": fakeFirstEntry},
+					{"entryhash": fakeEntry},
+				},
+			},
+		}),
+		"entry": byParam("hash", map[string]interface{}{
+			fakeFirstEntry: map[string]interface{}{
+				"chainid": fakeChainID,
+				"extids":  hexNames("myapp", "users"),
+				"content": "",
+			},
+		}),
+	})
+}
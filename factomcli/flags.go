@@ -0,0 +1,148 @@
+package factomcli
+
+import (
+	"strings"
+
+	"github.com/posener/complete"
+)
+
+// flagRules describes how the flags of a command may be combined. Flags that
+// are not repeatable may be given only once.
+type flagRules struct {
+	// repeatable flags may be given any number of times.
+	repeatable []string
+	// exclusive lists groups of alternatives of which only one may be
+	// used. Each alternative is a space separated list of flags and of
+	// the kinds of positional arguments, so {"-n -h", "-c"} allows any
+	// of -n and -h, or -c, and {"-n -h", "CHAINID"} allows any of -n and
+	// -h, or a CHAINID argument.
+	exclusive [][]string
+	// ends is true if the first positional argument ends the option
+	// list, as it does for the flag package.
+	ends bool
+	// args holds the kinds of the positional arguments in order. The last
+	// one also names any further arguments.
+	args []string
+}
+
+// commandRules holds the flagRules of commands by the space separated path of
+// sub command names below the program. All flags of commands without rules
+// are single-use.
+type commandRules map[string]flagRules
+
+// filterFlags removes the flag names from options that may not be given
+// after the arguments already on the line according to the rules of the
+// command being completed. If the positional argument being completed may
+// not be given, its candidates are removed as well.
+func (rules commandRules) filterFlags(root complete.Command, a complete.Args,
+	options []string) []string {
+	cmd, path, completed := subCommand(root, a.Completed)
+	if len(cmd.Flags) == 0 {
+		return options
+	}
+	if len(path) == 0 {
+		// The args of the program do not start with its name.
+		completed = append([]string{""}, completed...)
+	}
+	line := parseLine(cmd.Flags, completed)
+	if len(line.pending) > 0 {
+		// The options are the values of a flag.
+		return options
+	}
+
+	r := rules[strings.Join(path, " ")]
+	allowed := r.allowed(cmd.Flags, line)
+	kind, isArg := r.argKind(len(line.args))
+	var filtered []string
+	for _, option := range options {
+		name := strings.SplitN(option, descSep, 2)[0]
+		if _, isFlag := cmd.Flags[name]; isFlag {
+			if !allowed[name] {
+				continue
+			}
+		} else if isArg && !allowed[kind] {
+			continue
+		}
+		filtered = append(filtered, option)
+	}
+	return filtered
+}
+
+// allowed returns the flags and the kinds of positional arguments that may
+// still be given after the flags and arguments of line.
+func (r flagRules) allowed(flags complete.Flags,
+	line cmdLine) map[string]bool {
+	used := make(map[string]bool)
+	for _, f := range line.flags {
+		used[f.name] = true
+	}
+	for i := range line.args {
+		if kind, ok := r.argKind(i); ok {
+			used[kind] = true
+		}
+	}
+	ended := line.ended || r.ends && len(line.args) > 0
+
+	repeatable := make(map[string]bool)
+	for _, name := range r.repeatable {
+		repeatable[name] = true
+	}
+	allowed := make(map[string]bool)
+	for name := range flags {
+		allowed[name] = !ended && (repeatable[name] || !used[name])
+	}
+	for _, kind := range r.args {
+		allowed[kind] = true
+	}
+	for _, group := range r.exclusive {
+		chosen := -1
+		for i, alternative := range group {
+			for _, name := range strings.Fields(alternative) {
+				if used[name] {
+					chosen = i
+				}
+			}
+		}
+		if chosen < 0 {
+			continue
+		}
+		for i, alternative := range group {
+			if i == chosen {
+				continue
+			}
+			for _, name := range strings.Fields(alternative) {
+				allowed[name] = false
+			}
+		}
+	}
+	return allowed
+}
+
+// argKind returns the kind of the i-th positional argument, if the rules
+// know the arguments of the command.
+func (r flagRules) argKind(i int) (string, bool) {
+	if len(r.args) == 0 {
+		return "", false
+	}
+	if i >= len(r.args) {
+		i = len(r.args) - 1
+	}
+	return r.args[i], true
+}
+
+// subCommand returns the sub command of cmd that is being completed, the
+// names of the sub commands leading to it and the completed args starting at
+// its name. It finds the sub command the way complete does.
+func subCommand(cmd complete.Command, completed []string) (complete.Command,
+	[]string, []string) {
+	for i, arg := range completed {
+		if sub, ok := cmd.Sub[arg]; ok {
+			sub, path, rest := subCommand(sub, completed[i+1:])
+			if len(path) == 0 {
+				rest = completed[i:]
+			}
+			return sub, append([]string{arg}, path...), rest
+		}
+	}
+	return cmd, nil, completed
+}
@@ -0,0 +1,109 @@
+package factomcli
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/AdamSLevy/factom"
+	"github.com/posener/complete"
+)
+
+// heightWindow is the number of recent heights below the current height that
+// are suggested.
+const heightWindow = 10
+
+// PredictHeight predicts a block HEIGHT from the current heights of factomd.
+var PredictHeight = complete.PredictFunc(rpcPredictors.height)
+
+func (p predictors) height(complete.Args) []string {
+	var heights factom.HeightsResponse
+	if err := p.loadCached(cacheHeights, &heights); err != nil {
+		complete.Log("error: %v", err)
+		return nil
+	}
+	h := newHeightList()
+	h.add(heights.DirectoryBlockHeight, "directory block height")
+	h.add(heights.LeaderHeight, "leader height")
+	h.add(heights.EntryHeight, "entry height")
+	h.addWindow(heights.DirectoryBlockHeight)
+	return h.heights
+}
+
+// PredictRangeStart predicts the START of listtxs range from the height of
+// factom-walletd.
+var PredictRangeStart = complete.PredictFunc(rpcPredictors.rangeStart)
+
+func (p predictors) rangeStart(complete.Args) []string {
+	walletHeight, ok := p.walletHeight()
+	if !ok {
+		return nil
+	}
+	h := newHeightList()
+	h.add(walletHeight, "wallet height")
+	h.addWindow(walletHeight)
+	h.add(0, "genesis")
+	return h.heights
+}
+
+// PredictRangeEnd predicts the END of listtxs range. Every suggestion is at
+// least START.
+var PredictRangeEnd = PredictArgsFunc(rpcPredictors.rangeEnd)
+
+func (p predictors) rangeEnd(_ complete.Args, prev []string) []string {
+	if len(prev) < 1 {
+		return nil
+	}
+	start, err := strconv.ParseInt(prev[0], 10, 64)
+	if err != nil {
+		return nil
+	}
+	h := newHeightList()
+	if walletHeight, ok := p.walletHeight(); ok && walletHeight >= start {
+		h.add(walletHeight, "wallet height")
+	}
+	var heights factom.HeightsResponse
+	if err := p.loadCached(cacheHeights, &heights); err != nil {
+		complete.Log("error: %v", err)
+	} else if heights.DirectoryBlockHeight >= start {
+		h.add(heights.DirectoryBlockHeight, "directory block height")
+	}
+	h.add(start+heightWindow, fmt.Sprintf("START + %d", heightWindow))
+	return h.heights
+}
+
+// heightList collects unique height suggestions in order.
+type heightList struct {
+	heights []string
+	seen    map[int64]bool
+}
+
+func newHeightList() *heightList {
+	return &heightList{seen: make(map[int64]bool)}
+}
+
+func (h *heightList) add(height int64, desc string) {
+	if height < 0 || h.seen[height] {
+		return
+	}
+	h.seen[height] = true
+	h.heights = append(h.heights,
+		describe(strconv.FormatInt(height, 10), desc))
+}
+
+// addWindow adds the heightWindow heights below height.
+func (h *heightList) addWindow(height int64) {
+	h.add(height-1, "1 block ago")
+	for i := int64(2); i <= heightWindow; i++ {
+		h.add(height-i, fmt.Sprintf("%d blocks ago", i))
+	}
+}
+
+// walletHeight returns the height up to which factom-walletd has synced.
+func (p predictors) walletHeight() (int64, bool) {
+	height, err := p.client.GetWalletHeight()
+	if err != nil {
+		complete.Log("error: %v", err)
+		return 0, false
+	}
+	return int64(height), true
+}
@@ -0,0 +1,71 @@
+package factomcli
+
+import (
+	"strings"
+
+	"github.com/posener/complete"
+)
+
+// commandHelp maps the path of each command of a program to its spec. It
+// describes the sub commands and flags among the completion candidates.
+type commandHelp map[string]commandSpec
+
+// newCommandHelp returns the commandHelp of the specs of a program.
+func newCommandHelp(specs []commandSpec) commandHelp {
+	help := make(commandHelp, len(specs))
+	for _, spec := range specs {
+		help[spec.Path] = spec
+	}
+	return help
+}
+
+// describeOptions annotates the options that are sub commands or flags of
+// the command being completed in a with their help, and groups them.
+// Options that already have a description are left as they are.
+func (help commandHelp) describeOptions(cmd complete.Command, a complete.Args,
+	options []string) []string {
+	sub, path, _ := subCommand(cmd, a.Completed)
+	described := make([]string, len(options))
+	for i, option := range options {
+		described[i] = option
+		if strings.Contains(option, descSep) {
+			continue
+		}
+		if _, ok := sub.Sub[option]; ok {
+			spec := help[strings.Join(append(path, option), " ")]
+			described[i] = group(groupCommands,
+				describe(option, commandDescription(spec)))
+			continue
+		}
+		if _, ok := sub.Flags[option]; ok && strings.HasPrefix(option, "-") {
+			described[i] = group(groupFlags,
+				describe(option, help.flagDescription(path, option)))
+		}
+	}
+	return described
+}
+
+// commandDescription returns the usage of the command in spec without its
+// path, or its description if the usage has nothing more to say.
+func commandDescription(spec commandSpec) string {
+	words := strings.Fields(spec.Usage)
+	if n := len(strings.Fields(spec.Path)) + 1; len(words) > n {
+		return strings.Join(words[n:], " ")
+	}
+	return spec.Description
+}
+
+// flagDescription returns the description of the flag name of the command
+// at path, or else the name of its value.
+func (help commandHelp) flagDescription(path []string, name string) string {
+	for _, f := range help[strings.Join(path, " ")].Flags {
+		if f.Name != name {
+			continue
+		}
+		if len(f.Description) > 0 {
+			return f.Description
+		}
+		return f.Arg
+	}
+	return ""
+}
@@ -0,0 +1,109 @@
+package factomcli
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// installer runs the flags that install or uninstall the completion of
+// several programs, like those of complete's cmd.CLI do for one program.
+// Unlike complete's, it installs native completion functions for zsh and
+// fish, which show the descriptions and groups of the candidates.
+type installer struct {
+	install   bool
+	uninstall bool
+	yes       bool
+}
+
+// addInstallFlags adds the install flags to flags.
+func addInstallFlags(flags *flag.FlagSet) *installer {
+	var i installer
+	flags.BoolVar(&i.install, "install", false,
+		"Install completion for factom-cli, factomd and factom-walletd")
+	flags.BoolVar(&i.uninstall, "uninstall", false,
+		"Uninstall completion for factom-cli, factomd and factom-walletd")
+	flags.BoolVar(&i.yes, "y", false,
+		"Don't prompt user for typing 'yes' when installing completion")
+	return &i
+}
+
+// run installs or uninstalls the completion of the programs into the shells
+// that are configured in the home directory, as the flags ask.
+func (i *installer) run(programs []string) error {
+	if !i.install && !i.uninstall {
+		return nil
+	}
+	if i.install && i.uninstall {
+		return errors.New("install and uninstall are mutually exclusive")
+	}
+	bin, err := executable()
+	if err != nil {
+		return err
+	}
+	shells := installedShells(homeDir())
+	if len(shells) == 0 {
+		return errors.New("no bash, zsh or fish configuration found")
+	}
+
+	if !i.prompt(programs) {
+		return errCancelled
+	}
+	var failed bool
+	for _, sh := range shells {
+		action := sh.install
+		if i.uninstall {
+			action = sh.uninstall
+		}
+		if err := action(bin, programs); err != nil {
+			fmt.Printf("%s failed for %s! %s\n", i.action(), sh.name(), err)
+			failed = true
+		}
+	}
+	if failed {
+		return fmt.Errorf("%s failed", strings.ToLower(i.action()))
+	}
+	fmt.Println("Done!")
+	return nil
+}
+
+// errCancelled is returned by run if the user did not approve.
+var errCancelled = errors.New("cancelled")
+
+// executable returns the absolute path of the running binary.
+func executable() (string, error) {
+	bin, err := os.Executable()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Abs(bin)
+}
+
+// prompt asks for approval and returns whether it was given.
+func (i *installer) prompt(programs []string) bool {
+	if i.yes {
+		fmt.Println(i.action() + "ing...")
+		return true
+	}
+	fmt.Printf("%s completion for %s? ", i.action(),
+		strings.Join(programs, ", "))
+	var answer string
+	fmt.Scanln(&answer)
+	switch strings.ToLower(answer) {
+	case "y", "yes":
+		fmt.Println(i.action() + "ing...")
+		return true
+	}
+	fmt.Println("Cancelling...")
+	return false
+}
+
+func (i *installer) action() string {
+	if i.uninstall {
+		return "Uninstall"
+	}
+	return "Install"
+}
@@ -0,0 +1,54 @@
+// Command specgen generates the Go source of the command specs of a program
+// from its help texts.
+//
+// Usage:
+//
+//	specgen [-o FILE] [-pkg PACKAGE] [-program NAME] -var NAME DIR
+//
+// DIR holds a sub directory for each version of the program, named after the
+// version, with the help text of that version in help.txt.
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"os"
+
+	"github.com/AdamSLevy/complete-factom-cli/factomcli/internal/usage"
+)
+
+func main() {
+	out := flag.String("o", "", "write the source to `file` instead of stdout")
+	pkg := flag.String("pkg", "factomcli", "the `package` of the source")
+	program := flag.String("program", "factom-cli", "the `name` of the program")
+	name := flag.String("var", "", "the `name` of the variable to declare")
+	flag.Parse()
+	if flag.NArg() != 1 || len(*name) == 0 {
+		flag.Usage()
+		os.Exit(2)
+	}
+	dir := flag.Arg(0)
+
+	helps, err := usage.ParseDir(dir, *program)
+	if err != nil {
+		fatal(err)
+	}
+	var b bytes.Buffer
+	if err := usage.Generate(&b, dir, *pkg, *name, helps); err != nil {
+		fatal(err)
+	}
+	if len(*out) == 0 {
+		os.Stdout.Write(b.Bytes())
+		return
+	}
+	if err := ioutil.WriteFile(*out, b.Bytes(), 0644); err != nil {
+		fatal(err)
+	}
+}
+
+func fatal(err error) {
+	fmt.Fprintln(os.Stderr, "specgen:", err)
+	os.Exit(1)
+}
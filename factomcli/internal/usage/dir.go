@@ -0,0 +1,53 @@
+package usage
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+)
+
+// HelpFile is the name of the file that holds the help text of a version of
+// a program.
+const HelpFile = "help.txt"
+
+// Help holds the commands of a version of a program.
+type Help struct {
+	Version  string
+	Commands []Command
+}
+
+// ParseDir parses the help texts of program in dir. Each version of the
+// program has a sub directory named after the version that holds its help
+// text in HelpFile. The versions are in the order of their directory names.
+func ParseDir(dir, program string) ([]Help, error) {
+	infos, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	var helps []Help
+	for _, info := range infos {
+		if !info.IsDir() {
+			continue
+		}
+		cmds, err := parseFile(filepath.Join(dir, info.Name(), HelpFile),
+			program)
+		if err != nil {
+			return nil, err
+		}
+		helps = append(helps, Help{Version: info.Name(), Commands: cmds})
+	}
+	return helps, nil
+}
+
+func parseFile(path, program string) ([]Command, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	cmds, err := Parse(f, program)
+	if err != nil {
+		return nil, &os.PathError{Op: "parse", Path: path, Err: err}
+	}
+	return cmds, nil
+}
@@ -0,0 +1,94 @@
+package usage
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"io"
+)
+
+// Generate writes a Go source file of package pkg to w that declares the
+// commands of each version of a program as a variable of type
+// map[string][]commandSpec. The package must declare the types commandSpec,
+// flagSpec and argSpec with the fields of Command, Flag and Arg. The source
+// of the help texts is named in the header.
+func Generate(w io.Writer, source, pkg, name string, helps []Help) error {
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "// Code generated by specgen from %v. DO NOT EDIT.\n\n",
+		source)
+	fmt.Fprintf(&b, "package %v\n\n", pkg)
+	fmt.Fprintf(&b, "var %v = map[string][]commandSpec{\n", name)
+	for _, help := range helps {
+		fmt.Fprintf(&b, "%q: {\n", help.Version)
+		generateCommands(&b, help.Commands)
+		b.WriteString("},\n")
+	}
+	b.WriteString("}\n")
+
+	src, err := format.Source(b.Bytes())
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(src)
+	return err
+}
+
+// generateCommands writes the elements of a []commandSpec literal to b.
+func generateCommands(b *bytes.Buffer, cmds []Command) {
+	for _, cmd := range cmds {
+		b.WriteString("{\n")
+		fmt.Fprintf(b, "Path: %q,\n", cmd.Path)
+		if len(cmd.Usage) > 0 {
+			fmt.Fprintf(b, "Usage: %q,\n", cmd.Usage)
+		}
+		if len(cmd.Description) > 0 {
+			fmt.Fprintf(b, "Description: %q,\n", cmd.Description)
+		}
+		if len(cmd.Flags) > 0 {
+			b.WriteString("Flags: []flagSpec{\n")
+			for _, f := range cmd.Flags {
+				fmt.Fprintf(b, "{Name: %q", f.Name)
+				if len(f.Arg) > 0 {
+					fmt.Fprintf(b, ", Arg: %q", f.Arg)
+				}
+				if f.Repeatable {
+					b.WriteString(", Repeatable: true")
+				}
+				if len(f.Description) > 0 {
+					fmt.Fprintf(b, ", Description: %q", f.Description)
+				}
+				b.WriteString("},\n")
+			}
+			b.WriteString("},\n")
+		}
+		if len(cmd.Args) > 0 {
+			b.WriteString("Args: []argSpec{\n")
+			for _, a := range cmd.Args {
+				fmt.Fprintf(b, "{Kind: %q", a.Kind)
+				if a.Optional {
+					b.WriteString(", Optional: true")
+				}
+				if a.Variadic {
+					b.WriteString(", Variadic: true")
+				}
+				b.WriteString("},\n")
+			}
+			b.WriteString("},\n")
+		}
+		if len(cmd.Exclusive) > 0 {
+			b.WriteString("Exclusive: [][]string{\n")
+			for _, alts := range cmd.Exclusive {
+				b.WriteString("{")
+				for i, alt := range alts {
+					if i > 0 {
+						b.WriteString(", ")
+					}
+					fmt.Fprintf(b, "%q", alt)
+				}
+				b.WriteString("},\n")
+			}
+			b.WriteString("},\n")
+		}
+		b.WriteString("},\n")
+	}
+}
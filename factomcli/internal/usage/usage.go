@@ -0,0 +1,463 @@
+// Package usage parses the help text of a command line program into a
+// description of its commands, flags and positional arguments.
+//
+// The help text lists the flags of the program in the format of
+// flag.PrintDefaults and one usage line per command, such as
+//
+//	factom-cli addentry [-fq] [-n NAME1 -h HEXNAME2 ...|-c CHAINID] ECADDRESS <STDIN>
+//		Create a new Factom Entry.
+//
+// Any indented line that follows a flag or a usage line describes it.
+package usage
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+	"unicode"
+)
+
+// Command describes a command of a program.
+type Command struct {
+	// Path is the space separated list of sub command names below the
+	// program. It is empty for the program itself.
+	Path        string
+	Usage       string
+	Description string
+	Flags       []Flag
+	Args        []Arg
+	// Exclusive lists groups of alternatives of which only one may be
+	// used. Each alternative is a space separated list of flags and of the
+	// kinds of positional arguments.
+	Exclusive [][]string
+}
+
+// Flag describes a flag of a command.
+type Flag struct {
+	Name string
+	// Arg is the name of the value of the flag, such as NAME for -n NAME1.
+	// It is empty for boolean flags.
+	Arg string
+	// Repeatable is true if the flag may be given more than once.
+	Repeatable  bool
+	Description string
+}
+
+// Arg describes a positional argument of a command.
+type Arg struct {
+	// Kind is the name of the argument in the usage line without any
+	// number, such as ADDRESS or ECADDRESS|FCTADDRESS.
+	Kind     string
+	Optional bool
+	// Variadic is true if the argument may be repeated, as in
+	// ADDRESS [ADDRESS...].
+	Variadic bool
+}
+
+// Parse parses the help text of program read from r. The first command
+// describes the program itself and holds its flags. The other commands are
+// in the order of their first usage line.
+func Parse(r io.Reader, program string) ([]Command, error) {
+	p := parser{program: program, index: map[string]int{"": 0}}
+	p.commands = []Command{{}}
+	scanner := bufio.NewScanner(r)
+	for n := 1; scanner.Scan(); n++ {
+		if err := p.line(scanner.Text()); err != nil {
+			return nil, fmt.Errorf("line %d: %v", n, err)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return p.commands, nil
+}
+
+type parser struct {
+	program  string
+	commands []Command
+	// index holds the index of each command in commands by its path.
+	index map[string]int
+	// desc points to the description that indented lines continue.
+	desc *string
+}
+
+// line parses a single line of the help text.
+func (p *parser) line(line string) error {
+	text := strings.TrimSpace(line)
+	switch {
+	case len(text) == 0:
+		return nil
+	case strings.HasPrefix(line, "  -"):
+		// A flag in the format of flag.PrintDefaults. Single letter
+		// flags without a value are followed by their usage.
+		fields := strings.SplitN(text, "\t", 2)
+		words := strings.Fields(fields[0])
+		flag := Flag{Name: words[0]}
+		if len(words) > 1 {
+			flag.Arg = words[1]
+		}
+		if len(fields) > 1 {
+			flag.Description = strings.TrimSpace(fields[1])
+		}
+		root := &p.commands[0]
+		root.Flags = append(root.Flags, flag)
+		p.desc = &root.Flags[len(root.Flags)-1].Description
+	case unicode.IsSpace(rune(line[0])):
+		if p.desc == nil {
+			return nil
+		}
+		if len(*p.desc) > 0 {
+			*p.desc += " "
+		}
+		*p.desc += text
+	case strings.HasPrefix(text, p.program+" "):
+		cmds, err := parseUsage(p.program, text[len(p.program)+1:])
+		if err != nil {
+			return err
+		}
+		if len(cmds[0].Path) == 0 {
+			// The synopsis of the program.
+			p.commands[0].Usage = text
+			p.desc = &p.commands[0].Description
+			return nil
+		}
+		for _, cmd := range cmds[1:] {
+			p.add(cmd)
+		}
+		p.desc = &p.commands[p.add(cmds[0])].Description
+	default:
+		// A heading such as "Commands:".
+		p.desc = nil
+	}
+	return nil
+}
+
+// add adds cmd to the commands, or merges it into the command with the
+// same path, and returns its index.
+func (p *parser) add(cmd Command) int {
+	i, ok := p.index[cmd.Path]
+	if !ok {
+		i = len(p.commands)
+		p.index[cmd.Path] = i
+		p.commands = append(p.commands, cmd)
+		return i
+	}
+	c := &p.commands[i]
+	if len(c.Usage) == 0 {
+		c.Usage, c.Description = cmd.Usage, cmd.Description
+	}
+	if len(c.Args) == 0 {
+		c.Args = cmd.Args
+	}
+	for _, f := range cmd.Flags {
+		c.addFlag(f)
+	}
+	c.Exclusive = append(c.Exclusive, cmd.Exclusive...)
+	return i
+}
+
+// addFlag adds f to the flags of c. A flag that is added twice is
+// repeatable.
+func (c *Command) addFlag(f Flag) {
+	for i := range c.Flags {
+		if c.Flags[i].Name == f.Name {
+			c.Flags[i].Repeatable = true
+			return
+		}
+	}
+	c.Flags = append(c.Flags, f)
+}
+
+// parseUsage parses the usage line of a command without the program name.
+// A line that lists the sub commands of a command, as in get dblock|eblock,
+// only describes the parent command. If the line names an optional sub
+// command, as in listtxs [all] [-T], the flags of the line are also flags of
+// the parent command, which is returned after the command.
+func parseUsage(program, usage string) ([]Command, error) {
+	cmd := Command{Usage: program + " " + usage}
+	tokens := tokenize(usage)
+	i := 0
+	alts, err := parseItems(tokens, &i)
+	if err != nil {
+		return nil, err
+	}
+	if i < len(tokens) {
+		return nil, fmt.Errorf("unexpected %q", tokens[i])
+	}
+	if len(alts) > 1 {
+		return nil, fmt.Errorf("unexpected | outside of [...]")
+	}
+	items := alts[0]
+
+	var path []string
+	optional := -1
+	for len(items) > 0 {
+		name := items[0].word
+		if g := items[0].group; len(g) == 1 && len(g[0]) == 1 {
+			name = g[0][0].word
+		}
+		if isNameList(name) {
+			// The rest of the line lists the sub commands.
+			items = nil
+			break
+		}
+		if !isName(name) {
+			break
+		}
+		if items[0].group != nil && optional < 0 {
+			optional = len(path)
+		}
+		path = append(path, name)
+		items = items[1:]
+	}
+	cmd.Path = strings.Join(path, " ")
+	cmd.addItems(items, false)
+	cmds := []Command{cmd}
+	if optional >= 0 {
+		cmds = append(cmds, Command{
+			Path:  strings.Join(path[:optional], " "),
+			Flags: cmd.Flags,
+		})
+	}
+	return cmds, nil
+}
+
+// item is a word of a usage line or an optional group of alternatives
+// enclosed in [...].
+type item struct {
+	word  string
+	group [][]item
+}
+
+// ellipsis follows what may be repeated.
+const ellipsis = "..."
+
+// parseItems parses the tokens from i up to the next unmatched ] into
+// alternatives separated by |.
+func parseItems(tokens []string, i *int) ([][]item, error) {
+	alts := [][]item{nil}
+	for ; *i < len(tokens); *i++ {
+		last := len(alts) - 1
+		switch token := tokens[*i]; token {
+		case "]":
+			return alts, nil
+		case "|":
+			alts = append(alts, nil)
+		case "[":
+			*i++
+			group, err := parseItems(tokens, i)
+			if err != nil {
+				return nil, err
+			}
+			if *i >= len(tokens) {
+				return nil, fmt.Errorf("missing ]")
+			}
+			alts[last] = append(alts[last], item{group: group})
+		default:
+			alts[last] = append(alts[last], item{word: token})
+		}
+	}
+	return alts, nil
+}
+
+// addItems adds the flags and positional arguments of items to c and
+// returns the names of the flags.
+func (c *Command) addItems(items []item, optional bool) []string {
+	var flags []string
+	for i := 0; i < len(items); i++ {
+		it := items[i]
+		switch {
+		case it.group != nil:
+			c.addGroup(it.group)
+		case it.word == ellipsis:
+			// Anything before the ellipsis may be repeated.
+			for _, name := range flags {
+				c.setRepeatable(name)
+			}
+		case isFlag(it.word):
+			if i+1 < len(items) && isMetavar(items[i+1].word) {
+				i++
+				arg := items[i].word
+				c.addFlag(Flag{
+					Name:       it.word,
+					Arg:        kind(arg),
+					Repeatable: arg != kind(arg),
+				})
+				flags = append(flags, it.word)
+				continue
+			}
+			// Combined boolean flags such as -fq.
+			for _, r := range it.word[1:] {
+				name := "-" + string(r)
+				c.addFlag(Flag{Name: name})
+				flags = append(flags, name)
+			}
+		default:
+			arg := Arg{Kind: kind(it.word), Optional: optional}
+			if i+1 < len(items) && items[i+1].word == ellipsis {
+				arg.Variadic = true
+				i++
+			}
+			c.addArg(arg)
+		}
+	}
+	return flags
+}
+
+// addGroup adds the items of an optional group of alternatives to c. The
+// flags and positional arguments of different alternatives are exclusive,
+// as in [-n NAME ...|CHAINID].
+func (c *Command) addGroup(alts [][]item) {
+	var exclusive []string
+	for _, alt := range alts {
+		n := len(c.Args)
+		names := c.addItems(alt, true)
+		for _, a := range c.Args[n:] {
+			names = append(names, a.Kind)
+		}
+		if len(names) > 0 {
+			exclusive = append(exclusive, strings.Join(names, " "))
+		}
+	}
+	if len(exclusive) > 1 {
+		c.Exclusive = append(c.Exclusive, exclusive)
+	}
+}
+
+// addArg adds the positional argument a to c. An optional repetition of the
+// previous argument, as in ADDRESS [ADDRESS...], makes it variadic.
+func (c *Command) addArg(a Arg) {
+	if n := len(c.Args); n > 0 && a.Variadic && c.Args[n-1].Kind == a.Kind {
+		c.Args[n-1].Variadic = true
+		return
+	}
+	c.Args = append(c.Args, a)
+}
+
+func (c *Command) setRepeatable(name string) {
+	for i := range c.Flags {
+		if c.Flags[i].Name == name {
+			c.Flags[i].Repeatable = true
+		}
+	}
+}
+
+// tokenize splits a usage line into words and the tokens [, ], | and ...
+// Notes in parentheses and inputs in angle brackets, such as <STDIN>, are
+// dropped. Quotes around a word are removed. Alternatives of single words,
+// such as ECADDRESS|FCTADDRESS, are kept as one word.
+func tokenize(usage string) []string {
+	var tokens []string
+	fields := strings.Fields(usage)
+	for i := 0; i < len(fields); i++ {
+		field := fields[i]
+		if strings.HasPrefix(field, "(") {
+			for i < len(fields) && !strings.HasSuffix(fields[i], ")") {
+				i++
+			}
+			continue
+		}
+		if strings.HasPrefix(field, "<") && strings.HasSuffix(field, ">") {
+			continue
+		}
+		tokens = append(tokens, splitField(field)...)
+	}
+	return tokens
+}
+
+// splitField splits a field of a usage line into tokens.
+func splitField(field string) []string {
+	var tokens, tail []string
+	for strings.HasPrefix(field, "[") {
+		tokens = append(tokens, "[")
+		field = field[1:]
+	}
+	for {
+		if strings.HasSuffix(field, "]") {
+			tail = append([]string{"]"}, tail...)
+			field = field[:len(field)-1]
+		} else if strings.HasSuffix(field, ellipsis) {
+			tail = append([]string{ellipsis}, tail...)
+			field = field[:len(field)-len(ellipsis)]
+		} else {
+			break
+		}
+	}
+	field = strings.Trim(field, "'")
+	if parts := strings.Split(field, "|"); len(parts) > 1 && !isWordList(field) {
+		for i, part := range parts {
+			if i > 0 {
+				tokens = append(tokens, "|")
+			}
+			tokens = append(tokens, splitField(part)...)
+		}
+	} else if len(field) > 0 {
+		tokens = append(tokens, field)
+	}
+	return append(tokens, tail...)
+}
+
+// isWordList returns true if s is one or more words of letters and digits
+// separated by |.
+func isWordList(s string) bool {
+	for _, word := range strings.Split(s, "|") {
+		if len(word) == 0 {
+			return false
+		}
+		for _, r := range word {
+			if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// isName returns true if s is the name of a sub command.
+func isName(s string) bool {
+	if len(s) == 0 {
+		return false
+	}
+	for _, r := range s {
+		if !unicode.IsLower(r) && !unicode.IsDigit(r) {
+			return false
+		}
+	}
+	return true
+}
+
+// isNameList returns true if s lists the names of sub commands.
+func isNameList(s string) bool {
+	names := strings.Split(s, "|")
+	if len(names) < 2 {
+		return false
+	}
+	for _, name := range names {
+		if !isName(name) {
+			return false
+		}
+	}
+	return true
+}
+
+func isFlag(s string) bool {
+	return len(s) > 1 && s[0] == '-'
+}
+
+// isMetavar returns true if s names the value of a flag.
+func isMetavar(s string) bool {
+	if len(s) == 0 || isFlag(s) || s == ellipsis {
+		return false
+	}
+	return strings.ToUpper(s) == s
+}
+
+// kind returns the name of an argument without its trailing number, as in
+// NAME for NAME1.
+func kind(arg string) string {
+	if k := strings.TrimRightFunc(arg, unicode.IsDigit); len(k) > 0 {
+		return k
+	}
+	return arg
+}
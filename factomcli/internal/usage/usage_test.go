@@ -0,0 +1,169 @@
+package usage
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseUsage(t *testing.T) {
+	tests := []struct {
+		usage string
+		want  []Command
+	}{{
+		usage: "balance [-r] ADDRESS",
+		want: []Command{{
+			Path:  "balance",
+			Flags: []Flag{{Name: "-r"}},
+			Args:  []Arg{{Kind: "ADDRESS"}},
+		}},
+	}, {
+		usage: "addentry [-fq] [-n NAME1 -h HEXNAME2 ...|-c CHAINID] " +
+			"[-e EXTID1 -x HEXEXTID ...] ECADDRESS <STDIN>",
+		want: []Command{{
+			Path: "addentry",
+			Flags: []Flag{
+				{Name: "-f"},
+				{Name: "-q"},
+				{Name: "-n", Arg: "NAME", Repeatable: true},
+				{Name: "-h", Arg: "HEXNAME", Repeatable: true},
+				{Name: "-c", Arg: "CHAINID"},
+				{Name: "-e", Arg: "EXTID", Repeatable: true},
+				{Name: "-x", Arg: "HEXEXTID", Repeatable: true},
+			},
+			Args:      []Arg{{Kind: "ECADDRESS"}},
+			Exclusive: [][]string{{"-n -h", "-c"}},
+		}},
+	}, {
+		usage: "addchain [-n NAME1 -n NAME2 -h HEXNAME3 ] ECADDRESS",
+		want: []Command{{
+			Path: "addchain",
+			Flags: []Flag{
+				{Name: "-n", Arg: "NAME", Repeatable: true},
+				{Name: "-h", Arg: "HEXNAME", Repeatable: true},
+			},
+			Args: []Arg{{Kind: "ECADDRESS"}},
+		}},
+	}, {
+		usage: "get firstentry [-n NAME1 -h HEXNAME2 ...|CHAINID] [-E]",
+		want: []Command{{
+			Path: "get firstentry",
+			Flags: []Flag{
+				{Name: "-n", Arg: "NAME", Repeatable: true},
+				{Name: "-h", Arg: "HEXNAME", Repeatable: true},
+				{Name: "-E"},
+			},
+			Args:      []Arg{{Kind: "CHAINID", Optional: true}},
+			Exclusive: [][]string{{"-n -h", "CHAINID"}},
+		}},
+	}, {
+		usage: "get abheight HEIGHT -r (to suppress Raw Data)",
+		want: []Command{{
+			Path:  "get abheight",
+			Flags: []Flag{{Name: "-r"}},
+			Args:  []Arg{{Kind: "HEIGHT"}},
+		}},
+	}, {
+		usage: "get dblock|eblock",
+		want:  []Command{{Path: "get"}},
+	}, {
+		usage: "listtxs [address|all]",
+		want:  []Command{{Path: "listtxs"}},
+	}, {
+		usage: "listtxs [all] [-T]",
+		want: []Command{{
+			Path:  "listtxs all",
+			Flags: []Flag{{Name: "-T"}},
+		}, {
+			Path:  "listtxs",
+			Flags: []Flag{{Name: "-T"}},
+		}},
+	}, {
+		usage: "listtxs address [-T] ECADDRESS|FCTADDRESS",
+		want: []Command{{
+			Path:  "listtxs address",
+			Flags: []Flag{{Name: "-T"}},
+			Args:  []Arg{{Kind: "ECADDRESS|FCTADDRESS"}},
+		}},
+	}, {
+		usage: "importaddress ADDRESS [ADDRESS...]",
+		want: []Command{{
+			Path: "importaddress",
+			Args: []Arg{{Kind: "ADDRESS", Variadic: true}},
+		}},
+	}, {
+		usage: "importkoinify '12WORDS'",
+		want: []Command{{
+			Path: "importkoinify",
+			Args: []Arg{{Kind: "12WORDS"}},
+		}},
+	}}
+	for _, test := range tests {
+		got, err := parseUsage("factom-cli", test.usage)
+		if err != nil {
+			t.Errorf("%q: %v", test.usage, err)
+			continue
+		}
+		test.want[0].Usage = "factom-cli " + test.usage
+		if !reflect.DeepEqual(got, test.want) {
+			t.Errorf("%q:\ngot  %+v\nwant %+v", test.usage, got, test.want)
+		}
+	}
+}
+
+func TestParseUsageErrors(t *testing.T) {
+	for _, usage := range []string{
+		"addentry [-n NAME1",
+		"addentry -n NAME1]",
+		"status TXID | FULLTX",
+	} {
+		if _, err := parseUsage("factom-cli", usage); err == nil {
+			t.Errorf("%q: no error", usage)
+		}
+	}
+}
+
+func TestParse(t *testing.T) {
+	const help = `factom-cli [OPTIONS] SUBCOMMAND [OPTIONS]
+
+Options:
+  -s string
+    	IPAddr:port# of factomd API
+  -x	a short flag
+  -wallettls
+    	use TLS
+
+Commands:
+factom-cli listtxs [address|all]
+	List transactions.
+factom-cli listtxs address [-T] ADDRESS
+	List the transactions
+	of an address.
+`
+	got, err := Parse(strings.NewReader(help), "factom-cli")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []Command{{
+		Usage: "factom-cli [OPTIONS] SUBCOMMAND [OPTIONS]",
+		Flags: []Flag{
+			{Name: "-s", Arg: "string",
+				Description: "IPAddr:port# of factomd API"},
+			{Name: "-x", Description: "a short flag"},
+			{Name: "-wallettls", Description: "use TLS"},
+		},
+	}, {
+		Path:        "listtxs",
+		Usage:       "factom-cli listtxs [address|all]",
+		Description: "List transactions.",
+	}, {
+		Path:        "listtxs address",
+		Usage:       "factom-cli listtxs address [-T] ADDRESS",
+		Description: "List the transactions of an address.",
+		Flags:       []Flag{{Name: "-T"}},
+		Args:        []Arg{{Kind: "ADDRESS"}},
+	}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got  %+v\nwant %+v", got, want)
+	}
+}
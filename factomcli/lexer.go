@@ -0,0 +1,222 @@
+package factomcli
+
+import (
+	"os"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// word is a word of a command line with its quotes removed.
+type word struct {
+	text string
+	// quoted is true if any part of the word was quoted or escaped.
+	quoted bool
+	// open is the quote that is still open at the end of the line: ', "
+	// or $ for $'...'. It is zero if all quotes were closed.
+	open rune
+}
+
+// completionLine returns the command line being completed up to the cursor.
+// Bash and zsh's bashcompinit set COMP_POINT to the index of the cursor in
+// characters. Fish passes only the line up to the cursor.
+func completionLine() string {
+	line := os.Getenv("COMP_LINE")
+	point, err := strconv.Atoi(os.Getenv("COMP_POINT"))
+	if err != nil || point < 0 {
+		return line
+	}
+	for i := range line {
+		if point == 0 {
+			return line[:i]
+		}
+		point--
+	}
+	return line
+}
+
+// splitWords splits a command line into words at unquoted white space the
+// way a POSIX shell does. Single quotes, double quotes, $'...' and backslash
+// escapes are removed. A quote that is not closed extends to the end of the
+// line. If the line ends in unquoted white space, the last word is empty.
+func splitWords(line string) []word {
+	var (
+		words []word
+		cur   word
+		// inWord is true if cur has been started, possibly by an empty
+		// pair of quotes.
+		inWord bool
+		text   strings.Builder
+	)
+	rs := []rune(line)
+	for i := 0; i < len(rs); i++ {
+		r := rs[i]
+		switch cur.open {
+		case '\'':
+			if r == '\'' {
+				cur.open = 0
+				continue
+			}
+			text.WriteRune(r)
+			continue
+		case '"':
+			switch {
+			case r == '"':
+				cur.open = 0
+			case r == '\\' && i+1 < len(rs) &&
+				strings.ContainsRune("$`\"\\\n", rs[i+1]):
+				i++
+				if rs[i] != '\n' {
+					text.WriteRune(rs[i])
+				}
+			default:
+				text.WriteRune(r)
+			}
+			continue
+		case '$':
+			switch {
+			case r == '\'':
+				cur.open = 0
+			case r == '\\' && i+1 < len(rs):
+				var n int
+				r, n = ansiCEscape(rs[i+1:])
+				i += n
+				text.WriteRune(r)
+			default:
+				text.WriteRune(r)
+			}
+			continue
+		}
+
+		switch {
+		case r == '\\':
+			cur.quoted, inWord = true, true
+			if i+1 < len(rs) {
+				i++
+				if rs[i] != '\n' {
+					text.WriteRune(rs[i])
+				}
+			}
+		case r == '\'' || r == '"':
+			cur.open, cur.quoted, inWord = r, true, true
+		case r == '$' && i+1 < len(rs) && rs[i+1] == '\'':
+			i++
+			cur.open, cur.quoted, inWord = '$', true, true
+		case unicode.IsSpace(r):
+			if inWord {
+				cur.text = text.String()
+				words = append(words, cur)
+				cur, inWord = word{}, false
+				text.Reset()
+			}
+		default:
+			text.WriteRune(r)
+			inWord = true
+		}
+	}
+	cur.text = text.String()
+	return append(words, cur)
+}
+
+// ansiCEscape decodes the escape sequence following a backslash in $'...'.
+// It returns the decoded rune and the number of runes of rs that it used.
+func ansiCEscape(rs []rune) (rune, int) {
+	simple := map[rune]rune{
+		'a': '\a', 'b': '\b', 'e': 0x1b, 'E': 0x1b, 'f': '\f', 'n': '\n',
+		'r': '\r', 't': '\t', 'v': '\v', '\\': '\\', '\'': '\'',
+		'"': '"', '?': '?',
+	}
+	if r, ok := simple[rs[0]]; ok {
+		return r, 1
+	}
+	switch rs[0] {
+	case 'x':
+		return numericEscape(rs, 1, 2, 16)
+	case 'u':
+		return numericEscape(rs, 1, 4, 16)
+	case 'U':
+		return numericEscape(rs, 1, 8, 16)
+	case 'c':
+		if len(rs) > 1 {
+			return rs[1] & 0x1f, 2
+		}
+	case '0', '1', '2', '3', '4', '5', '6', '7':
+		return numericEscape(rs, 0, 3, 8)
+	}
+	// Unknown escapes are left as they are.
+	return '\\', 0
+}
+
+// numericEscape decodes up to max digits of the given base in rs starting at
+// start. Without any digits, the escape is left as it is.
+func numericEscape(rs []rune, start, max, base int) (rune, int) {
+	end := start
+	for end < len(rs) && end-start < max && digitValue(rs[end]) < base {
+		end++
+	}
+	if end == start {
+		return '\\', 0
+	}
+	n, err := strconv.ParseUint(string(rs[start:end]), base, 32)
+	if err != nil || !utf8.ValidRune(rune(n)) {
+		return utf8.RuneError, end
+	}
+	return rune(n), end
+}
+
+// digitValue returns the value of the hex digit r, or 16 if r is not one.
+func digitValue(r rune) int {
+	switch {
+	case '0' <= r && r <= '9':
+		return int(r - '0')
+	case 'a' <= r && r <= 'f':
+		return int(r-'a') + 10
+	case 'A' <= r && r <= 'F':
+		return int(r-'A') + 10
+	}
+	return 16
+}
+
+// bashSpecial are the characters that bash treats specially in a word.
+const bashSpecial = " \t\n\\'\"`$|&;()<>[]{}*?!#~"
+
+// bashQuote quotes a completion candidate for insertion by bash into the
+// word being completed. Inside an open quote only the characters that end or
+// are special in that quote are escaped. Otherwise every character that is
+// special to bash is escaped with a backslash.
+func bashQuote(s string, open rune) string {
+	var b strings.Builder
+	for _, r := range s {
+		switch {
+		case open == '\'' && r == '\'':
+			b.WriteString(`'\''`)
+		case open == '"' && strings.ContainsRune("$`\"\\", r),
+			open == '$' && (r == '\\' || r == '\''),
+			open == 0 && strings.ContainsRune(bashSpecial, r):
+			b.WriteRune('\\')
+			b.WriteRune(r)
+		default:
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+// shellQuote quotes s as a single word for a POSIX shell.
+func shellQuote(s string) string {
+	if len(s) > 0 && !strings.ContainsAny(s, bashSpecial) {
+		return s
+	}
+	return "'" + strings.Replace(s, "'", `'\''`, -1) + "'"
+}
+
+// shellQuoteAll quotes each of args with shellQuote and joins them with
+// spaces.
+func shellQuoteAll(args []string) string {
+	quoted := make([]string, len(args))
+	for i, arg := range args {
+		quoted[i] = shellQuote(arg)
+	}
+	return strings.Join(quoted, " ")
+}
@@ -0,0 +1,57 @@
+package factomcli
+
+import (
+	"strings"
+
+	"github.com/AdamSLevy/factom"
+	"github.com/FactomProject/go-bip39"
+	"github.com/posener/complete"
+)
+
+// mnemonicLength is the number of words of a koinify mnemonic.
+const mnemonicLength = 12
+
+// PredictMnemonic completes the word being typed in the quoted 12WORDS of
+// importkoinify from the BIP39 English wordlist. Each candidate is the
+// entire mnemonic typed so far, since the shell completes the whole quoted
+// argument. Once a candidate has 12 words, it is described by whether its
+// checksum is valid and the valid ones are listed first.
+//
+// The mnemonic is a secret. It must never be logged or cached.
+var PredictMnemonic = complete.PredictFunc(func(a complete.Args) []string {
+	typed := strings.Fields(a.Last)
+	var prefix string
+	if len(typed) > 0 && !strings.HasSuffix(a.Last, " ") {
+		prefix = typed[len(typed)-1]
+		typed = typed[:len(typed)-1]
+	}
+	if len(typed) >= mnemonicLength {
+		return nil
+	}
+	for _, w := range typed {
+		if _, ok := bip39.ReverseWordMap[strings.ToLower(w)]; !ok {
+			return nil
+		}
+	}
+
+	head := a.Last[:len(a.Last)-len(prefix)]
+	// Mnemonics with an invalid checksum are listed after the valid ones.
+	var mnemonics, invalid []string
+	for _, w := range bip39.WordList {
+		if len(w) == 0 || !strings.HasPrefix(w, prefix) {
+			continue
+		}
+		mnemonic := head + w
+		if len(typed) < mnemonicLength-1 || !showDescriptions() {
+			mnemonics = append(mnemonics, mnemonic)
+			continue
+		}
+		if _, err := factom.ParseAndValidateMnemonic(mnemonic); err != nil {
+			invalid = append(invalid, describe(mnemonic,
+				"invalid checksum, check the words and their order"))
+			continue
+		}
+		mnemonics = append(mnemonics, describe(mnemonic, "valid checksum"))
+	}
+	return append(mnemonics, invalid...)
+})
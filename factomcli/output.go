@@ -0,0 +1,148 @@
+package factomcli
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"strings"
+)
+
+// descSep separates a candidate from its description, and the description
+// from the group of the candidate. The candidates are written in the format
+// of the shell requesting completion by newOutput.
+const descSep = "\t"
+
+// The groups under which zsh lists the candidates.
+const (
+	groupFCTAddresses = "FCT addresses"
+	groupECAddresses  = "EC addresses"
+	groupTmpTxs       = "tmp transactions"
+	groupCommands     = "commands"
+	groupFlags        = "flags"
+	groupValues       = "values"
+)
+
+// describe returns the candidate word annotated with desc. Shells without
+// support for descriptions only receive the word.
+func describe(word, desc string) string {
+	if len(desc) == 0 {
+		return word
+	}
+	return word + descSep + desc
+}
+
+// group returns the candidate, which may be described, as a member of the
+// named group.
+func group(name, candidate string) string {
+	if !strings.Contains(candidate, descSep) {
+		candidate += descSep
+	}
+	return candidate + descSep + name
+}
+
+// inGroup returns the candidates as members of the named group.
+func inGroup(name string, candidates []string) []string {
+	grouped := make([]string, len(candidates))
+	for i, c := range candidates {
+		grouped[i] = group(name, c)
+	}
+	return grouped
+}
+
+// splitCandidate returns the word, description and group of a candidate.
+func splitCandidate(candidate string) (word, desc, group string) {
+	parts := strings.SplitN(candidate, descSep, 3)
+	parts = append(parts, "", "")
+	return parts[0], parts[1], parts[2]
+}
+
+// completionShell returns the name of the shell that requested completion.
+// Completion functions may set COMP_SHELL. Otherwise bash and zsh's
+// bashcompinit set COMP_POINT, and fish does not.
+func completionShell() string {
+	if shell := os.Getenv("COMP_SHELL"); len(shell) > 0 {
+		return shell
+	}
+	if _, ok := os.LookupEnv("COMP_POINT"); !ok {
+		return "fish"
+	}
+	return "bash"
+}
+
+// showDescriptions returns true if the shell requesting completion can
+// display candidate descriptions.
+func showDescriptions() bool {
+	switch completionShell() {
+	case "zsh", "fish":
+		return true
+	}
+	return false
+}
+
+// newOutput returns a writer for the completion candidates that formats
+// them for the shell requesting completion.
+func newOutput(w io.Writer) io.Writer {
+	switch completionShell() {
+	case "zsh":
+		return formatOutput{w, zshCandidate}
+	case "fish":
+		return formatOutput{w, fishCandidate}
+	}
+	return formatOutput{w, plainCandidate}
+}
+
+// plainCandidate returns only the word of a candidate.
+func plainCandidate(candidate string) string {
+	word, _, _ := splitCandidate(candidate)
+	return word
+}
+
+// fishCandidate returns the candidate as "word\tdescription" as expected by
+// complete -a. Candidates without a description are described by their
+// group.
+func fishCandidate(candidate string) string {
+	word, desc, group := splitCandidate(candidate)
+	if len(desc) == 0 {
+		desc = group
+	}
+	return describe(word, desc)
+}
+
+// zshCandidate returns the candidate as "group\tword:description". Our zsh
+// completion function passes the candidates of each group to _describe,
+// which expects colons in the word to be escaped.
+func zshCandidate(candidate string) string {
+	word, desc, group := splitCandidate(candidate)
+	if len(group) == 0 {
+		group = groupValues
+	}
+	word = strings.Replace(word, ":", `\:`, -1)
+	if len(desc) > 0 {
+		word += ":" + desc
+	}
+	return group + descSep + word
+}
+
+// formatOutput formats each line written to it with format.
+type formatOutput struct {
+	w      io.Writer
+	format func(candidate string) string
+}
+
+func (f formatOutput) Write(b []byte) (int, error) {
+	var out bytes.Buffer
+	for _, line := range bytes.SplitAfter(b, []byte("\n")) {
+		if len(line) == 0 {
+			continue
+		}
+		text := strings.TrimSuffix(string(line), "\n")
+		out.WriteString(f.format(text))
+		if bytes.HasSuffix(line, []byte("\n")) {
+			out.WriteByte('\n')
+		}
+	}
+	if _, err := f.w.Write(out.Bytes()); err != nil {
+		return 0, err
+	}
+	return len(b), nil
+}
@@ -0,0 +1,202 @@
+package factomcli
+
+import (
+	"fmt"
+
+	"github.com/AdamSLevy/factom"
+	"github.com/posener/complete"
+)
+
+// PredictTxName predicts the names of all tmp transactions.
+var PredictTxName = rpcPredictors.txNames(nil)
+
+// PredictUnsignedTxName predicts the tmp transactions that can still be
+// modified.
+var PredictUnsignedTxName = rpcPredictors.txNames(unsignedTx)
+
+// PredictSignableTxName predicts the unsigned tmp transactions that have at
+// least one input and one output.
+var PredictSignableTxName = rpcPredictors.txNames(signableTx)
+
+// PredictSignedTxName predicts the tmp transactions that are ready to be
+// composed or sent.
+var PredictSignedTxName = rpcPredictors.txNames(signedTx)
+
+func unsignedTx(tx *factom.Transaction) bool {
+	return !tx.IsSigned
+}
+
+func signableTx(tx *factom.Transaction) bool {
+	return !tx.IsSigned && len(tx.Inputs) > 0 &&
+		len(tx.Outputs)+len(tx.ECOutputs) > 0
+}
+
+func signedTx(tx *factom.Transaction) bool {
+	return tx.IsSigned
+}
+
+// txNames returns a predictor for the names of the tmp transactions for
+// which filter returns true. A nil filter allows all tmp transactions.
+func (p predictors) txNames(
+	filter func(*factom.Transaction) bool) complete.PredictFunc {
+	return complete.PredictFunc(func(complete.Args) []string {
+		return p.listTxNames(filter)
+	})
+}
+
+// PredictAddress predicts the FCT and EC addresses in the wallet.
+var PredictAddress = complete.PredictFunc(rpcPredictors.address)
+
+// PredictFCTAddress predicts the FCT addresses in the wallet.
+var PredictFCTAddress = complete.PredictFunc(rpcPredictors.fctAddress)
+
+// PredictECAddress predicts the EC addresses in the wallet.
+var PredictECAddress = complete.PredictFunc(rpcPredictors.ecAddress)
+
+// PredictTxInputAddress predicts the input addresses of the tmp transaction
+// named by the first positional argument.
+var PredictTxInputAddress = PredictArgsFunc(rpcPredictors.txInputAddress)
+
+func (p predictors) txInputAddress(_ complete.Args, prev []string) []string {
+	tx := p.tmpTransaction(prev)
+	if tx == nil {
+		return nil
+	}
+	return inGroup(groupFCTAddresses,
+		describeTxAddresses(tx.Inputs, "input"))
+}
+
+// PredictTxOutputAddress predicts the output addresses of the tmp transaction
+// named by the first positional argument.
+var PredictTxOutputAddress = PredictArgsFunc(rpcPredictors.txOutputAddress)
+
+func (p predictors) txOutputAddress(_ complete.Args, prev []string) []string {
+	tx := p.tmpTransaction(prev)
+	if tx == nil {
+		return nil
+	}
+	return inGroup(groupFCTAddresses,
+		describeTxAddresses(tx.Outputs, "output"))
+}
+
+// PredictTxNewInputAddress predicts the funded FCT addresses that are not yet
+// inputs of the tmp transaction named by the first positional argument. If
+// the balances predictor is disabled, unfunded addresses are predicted too.
+var PredictTxNewInputAddress = PredictArgsFunc(rpcPredictors.txNewInputAddress)
+
+func (p predictors) txNewInputAddress(_ complete.Args, prev []string) []string {
+	tx := p.tmpTransaction(prev)
+	if tx == nil {
+		return nil
+	}
+	inputs := make(map[string]bool)
+	for _, in := range tx.Inputs {
+		inputs[in.Address] = true
+	}
+	fcts, _ := p.addressPubStrings()
+	var candidates []string
+	for _, fct := range fcts {
+		if !inputs[fct] {
+			candidates = append(candidates, fct)
+		}
+	}
+
+	if !predictorEnabled("balances") {
+		return inGroup(groupFCTAddresses, candidates)
+	}
+	amounts := p.fetchBalances(candidates, nil)
+	var funded []string
+	for _, fct := range candidates {
+		if amount := amounts[fct]; amount > 0 {
+			funded = append(funded, describe(fct,
+				factom.FactoshiToFactoid(uint64(amount))+" FCT"))
+		}
+	}
+	return inGroup(groupFCTAddresses, funded)
+}
+
+// tmpTransaction returns the tmp transaction named by the first of the
+// positional arguments in prev.
+func (p predictors) tmpTransaction(prev []string) *factom.Transaction {
+	if len(prev) == 0 {
+		return nil
+	}
+	txs, err := p.client.ListTransactionsTmp()
+	if err != nil {
+		complete.Log("error: %v", err)
+		return nil
+	}
+	for _, tx := range txs {
+		if tx.Name == prev[0] {
+			return tx
+		}
+	}
+	return nil
+}
+
+// describeTxAddresses returns the addresses of a transaction's inputs or
+// outputs annotated with their amounts.
+func describeTxAddresses(addresses []*factom.TransAddress, kind string) []string {
+	described := make([]string, len(addresses))
+	for i, a := range addresses {
+		described[i] = describe(a.Address, fmt.Sprintf("%v %v FCT",
+			kind, factom.FactoshiToFactoid(a.Amount)))
+	}
+	return described
+}
+
+func (p predictors) listTxNames(
+	filter func(*factom.Transaction) bool) []string {
+	var txs []*factom.Transaction
+	if err := p.loadCached(cacheTmpTxs, &txs); err != nil {
+		complete.Log("error: %v", err)
+		return nil
+	}
+	var txNames []string
+	for _, tx := range txs {
+		if filter == nil || filter(tx) {
+			txNames = append(txNames, describe(tx.Name, describeTx(tx)))
+		}
+	}
+	return inGroup(groupTmpTxs, txNames)
+}
+
+// describeTx summarizes the inputs, outputs, fee and signed state of tx.
+func describeTx(tx *factom.Transaction) string {
+	signed := "unsigned"
+	if tx.IsSigned {
+		signed = "signed"
+	}
+	return fmt.Sprintf("in: %v FCT, out: %v FCT, fee: %v FCT, %v",
+		factom.FactoshiToFactoid(tx.TotalInputs),
+		factom.FactoshiToFactoid(tx.TotalOutputs+tx.TotalECOutputs),
+		factom.FactoshiToFactoid(tx.FeesPaid), signed)
+}
+
+func (p predictors) ecAddress(complete.Args) []string {
+	_, ecs := p.addressPubStrings()
+	_, ecs = p.describeBalances(nil, ecs)
+	return inGroup(groupECAddresses, ecs)
+}
+
+func (p predictors) fctAddress(complete.Args) []string {
+	fcts, _ := p.addressPubStrings()
+	fcts, _ = p.describeBalances(fcts, nil)
+	return inGroup(groupFCTAddresses, fcts)
+}
+
+func (p predictors) address(complete.Args) []string {
+	fcts, ecs := p.addressPubStrings()
+	fcts, ecs = p.describeBalances(fcts, ecs)
+	return append(inGroup(groupFCTAddresses, fcts),
+		inGroup(groupECAddresses, ecs)...)
+}
+
+func (p predictors) addressPubStrings() ([]string, []string) {
+	var addresses addressesCache
+	if err := p.loadCached(cacheAddresses, &addresses); err != nil {
+		complete.Log("error: %v", err)
+		return nil, nil
+	}
+	return addresses.FCT, addresses.EC
+}
@@ -0,0 +1,144 @@
+package factomcli
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/posener/complete"
+	"github.com/posener/complete/match"
+)
+
+// secretCommands are the commands whose arguments must never be logged.
+var secretCommands = map[string]bool{
+	"importkoinify": true,
+}
+
+// run completes the command line in COMP_LINE like (*complete.Complete).Run
+// with the program named by its first word and writes the candidates to out,
+// or runs the install flags in args if there is none. Unlike complete, only
+// the line up to the cursor is completed and it is split into words the way
+// the shell does, so a quoted argument that is still being typed is a single
+// word. Flags are only offered if the rules of their command allow them. The
+// -script flag writes a static completion script to out instead, and the
+// doctor command a diagnosis of the completion.
+func run(programs []program, args []string, out io.Writer) error {
+	flags := flag.NewFlagSet("complete-factom-cli", flag.ContinueOnError)
+	inst := addInstallFlags(flags)
+	script := flags.String("script", "", "Write a static completion script "+
+		"for `SHELL` (bash, zsh or fish) that works without this program")
+	if err := flags.Parse(args); err != nil {
+		if err == flag.ErrHelp {
+			return nil
+		}
+		return err
+	}
+	names := make([]string, len(programs))
+	for i, p := range programs {
+		names[i] = p.name
+	}
+	if flags.Arg(0) == "doctor" {
+		if !runDoctor(out, names, flags.Args()[1:]) {
+			return errDoctorFailed
+		}
+		return nil
+	}
+	if len(*script) > 0 {
+		return writeStaticScript(out, *script, programs)
+	}
+	if len(os.Getenv("COMP_LINE")) == 0 {
+		return inst.run(names)
+	}
+	p := lineProgram(programs)
+	completeLine(p, newOutput(out))
+	return nil
+}
+
+// errDoctorFailed is returned by run if any check of the doctor command
+// failed.
+var errDoctorFailed = errors.New("the doctor found problems")
+
+// lineProgram returns the program named by the first word of the command
+// line in COMP_LINE, or else the first program.
+func lineProgram(programs []program) program {
+	name := filepath.Base(splitWords(completionLine())[0].text)
+	name = strings.TrimSuffix(name, ".exe")
+	for _, p := range programs {
+		if p.name == name {
+			return p
+		}
+	}
+	return programs[0]
+}
+
+// completeLine writes the candidates for the command line in COMP_LINE that
+// the command and rules of p predict to out, described by its help.
+func completeLine(p program, out io.Writer) {
+	cmd := p.command
+	line := completionLine()
+	words := splitWords(line)
+	a := newArgs(words)
+	secret := isSecret(a)
+	if !secret {
+		complete.Log("Completing line: %s", line)
+	}
+	options := p.rules.filterFlags(cmd, a, cmd.Predict(a))
+	options = p.help.describeOptions(cmd, a, options)
+	open := words[len(words)-1].open
+	for _, option := range options {
+		if !match.Prefix(option, a.Last) {
+			continue
+		}
+		if completionShell() == "bash" {
+			// Bash inserts candidates as they are.
+			parts := strings.SplitN(option, descSep, 2)
+			parts[0] = bashQuote(parts[0], open)
+			option = strings.Join(parts, descSep)
+		}
+		fmt.Fprintln(out, option)
+	}
+	if !secret {
+		complete.Log("Options: %s", options)
+	}
+}
+
+// isSecret returns true if the line runs one of the secretCommands.
+func isSecret(a complete.Args) bool {
+	for _, arg := range a.All {
+		if secretCommands[arg] {
+			return true
+		}
+	}
+	return false
+}
+
+// newArgs returns the complete.Args of the words of a command line. The
+// first word is the program name. As in complete, a last word that is not
+// quoted is split on "=" so that -flag=value completes the value.
+func newArgs(words []word) complete.Args {
+	var all []string
+	for _, w := range words[1:] {
+		all = append(all, w.text)
+	}
+	if n := len(words); n > 1 && !words[n-1].quoted {
+		if i := strings.Index(all[n-2], "="); i >= 0 {
+			last := all[n-2]
+			all = append(all[:n-2], last[:i], last[i+1:])
+		}
+	}
+
+	var a complete.Args
+	a.All = all
+	if len(all) > 0 {
+		a.Completed = all[:len(all)-1]
+		a.Last = all[len(all)-1]
+	}
+	if len(a.Completed) > 0 {
+		a.LastCompleted = a.Completed[len(a.Completed)-1]
+	}
+	return a
+}
@@ -0,0 +1,85 @@
+package factomcli
+
+import (
+	"github.com/posener/complete"
+)
+
+// newFactomdCommand returns the completion command tree of factomd and the
+// rules for combining its flags.
+func newFactomdCommand() (complete.Command, commandRules) {
+	return buildCommand(selectSpecs(factomdSpecs, ""), factomdPredictors,
+		nil)
+}
+
+// newWalletdCommand returns the completion command tree of factom-walletd
+// and the rules for combining its flags.
+func newWalletdCommand() (complete.Command, commandRules) {
+	return buildCommand(selectSpecs(walletdSpecs, ""), walletdPredictors,
+		walletdRules)
+}
+
+// FactomdCommand returns the completion command tree of factomd.
+func FactomdCommand() complete.Command {
+	cmd, _ := newFactomdCommand()
+	return cmd
+}
+
+// WalletdCommand returns the completion command tree of factom-walletd.
+func WalletdCommand() complete.Command {
+	cmd, _ := newWalletdCommand()
+	return cmd
+}
+
+// factomdPredictors predict the flags of factomd.
+var factomdPredictors = predictorTable{
+	"-network": predictSet("MAIN", "TEST", "LOCAL", "CUSTOM"),
+	"-db":      predictSet("Map", "LDB", "Bolt"),
+	"-loglvl": predictSet("none", "debug", "info", "warning",
+		"error", "fatal", "panic"),
+	"-controlpanelsetting": predictSet("disabled", "readonly",
+		"readwrite"),
+	"-net": predictSet("tree", "circles", "long", "loops",
+		"alot", "alot+", "square", "file"),
+
+	"-config":       predictFiles("*.conf"),
+	"-fnet":         predictFiles("*"),
+	"-journal":      predictFiles("*"),
+	"-stderrlog":    predictFiles("*"),
+	"-stdoutlog":    predictFiles("*"),
+	"-factomhome":   predictDirs("*"),
+	"-fastlocation": predictDirs("*"),
+	"-plugin":       predictDirs("*"),
+
+	"-clonedb":      complete.PredictAnything,
+	"-customnet":    complete.PredictAnything,
+	"-debugconsole": complete.PredictAnything,
+	"-debuglog":     complete.PredictAnything,
+	"-logPort":      complete.PredictAnything,
+	"-nodename":     complete.PredictAnything,
+	"-p2pPort":      complete.PredictAnything,
+	"-peers":        complete.PredictAnything,
+	"-prefix":       complete.PredictAnything,
+	"-rpcpass":      complete.PredictAnything,
+	"-rpcuser":      complete.PredictAnything,
+	"-selfaddr":     complete.PredictAnything,
+}
+
+// walletdPredictors predict the flags of factom-walletd.
+var walletdPredictors = predictorTable{
+	"-cert":        predictFiles("*"),
+	"-key":         predictFiles("*"),
+	"-factomdcert": predictFiles("*"),
+	"-i":           predictFiles("*"),
+	"-w":           predictFiles("*"),
+
+	"-factomdpassword": complete.PredictAnything,
+	"-factomduser":     complete.PredictAnything,
+	"-password":        complete.PredictAnything,
+	"-s":               complete.PredictAnything,
+	"-user":            complete.PredictAnything,
+}
+
+// walletdRules allow only one wallet database.
+var walletdRules = commandRules{
+	"": {exclusive: [][]string{{"-b", "-l", "-m"}}},
+}
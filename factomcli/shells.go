@@ -0,0 +1,343 @@
+package factomcli
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/template"
+)
+
+// shell installs the completion of programs by the binary bin into the
+// configuration of a shell.
+type shell interface {
+	name() string
+	install(bin string, programs []string) error
+	uninstall(bin string, programs []string) error
+	// installed returns the programs whose completion by any binary is
+	// installed.
+	installed(programs []string) []string
+}
+
+// installedShells returns the shells whose configuration exists in home.
+func installedShells(home string) []shell {
+	var shells []shell
+	if rc := filepath.Join(home, ".bashrc"); fileExists(rc) {
+		shells = append(shells, bashShell{rc})
+	}
+	if rc := filepath.Join(home, ".zshrc"); fileExists(rc) {
+		script := filepath.Join(home, ".factom", "complete-factom-cli",
+			"complete.zsh")
+		shells = append(shells, zshShell{rc, script})
+	}
+	if dir := fishConfigDir(home); fileExists(dir) {
+		shells = append(shells, fishShell{filepath.Join(dir, "completions")})
+	}
+	return shells
+}
+
+// fishConfigDir returns the configuration directory of fish.
+func fishConfigDir(home string) string {
+	if config := os.Getenv("XDG_CONFIG_HOME"); len(config) > 0 {
+		return filepath.Join(config, "fish")
+	}
+	return filepath.Join(home, ".config", "fish")
+}
+
+// bashShell completes the programs with "complete -C" lines in its rc file.
+type bashShell struct {
+	rc string
+}
+
+func (bashShell) name() string { return "bash" }
+
+func (b bashShell) install(bin string, programs []string) error {
+	return addLines(b.rc, bashLines(bin, programs))
+}
+
+func (b bashShell) uninstall(bin string, programs []string) error {
+	return removeLines(b.rc, bashLines(bin, programs))
+}
+
+func (b bashShell) installed(programs []string) []string {
+	return completedPrograms(readLines(b.rc), "complete -C ", programs)
+}
+
+func bashLines(bin string, programs []string) []string {
+	lines := make([]string, len(programs))
+	for i, program := range programs {
+		lines[i] = fmt.Sprintf("complete -C %s %s", shellQuote(bin), program)
+	}
+	return lines
+}
+
+// zshShell completes the programs with a completion function in script,
+// which its rc file sources. Installing replaces the bashcompinit based
+// completion of earlier versions.
+type zshShell struct {
+	rc     string
+	script string
+}
+
+func (zshShell) name() string { return "zsh" }
+
+func (z zshShell) install(bin string, programs []string) error {
+	if err := writeScript(z.script, zshScript, bin, programs); err != nil {
+		return err
+	}
+	if err := removeLines(z.rc, zshLegacyLines(bin, programs)); err != nil {
+		return err
+	}
+	return addLines(z.rc, []string{z.sourceLine()})
+}
+
+func (z zshShell) uninstall(bin string, programs []string) error {
+	lines := append(zshLegacyLines(bin, programs), z.sourceLine())
+	if err := removeLines(z.rc, lines); err != nil {
+		return err
+	}
+	if err := os.Remove(z.script); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
+
+func (z zshShell) installed(programs []string) []string {
+	lines := readLines(z.rc)
+	installed := completedPrograms(lines, "complete -o nospace -C ",
+		programs)
+	for _, line := range lines {
+		if line != z.sourceLine() {
+			continue
+		}
+		for _, line := range readLines(z.script) {
+			words := strings.Fields(line)
+			if len(words) < 2 || words[0] != "compdef" {
+				continue
+			}
+			for _, program := range programs {
+				if containsString(words[2:], program) {
+					installed = append(installed, program)
+				}
+			}
+		}
+	}
+	return installed
+}
+
+func (z zshShell) sourceLine() string {
+	return "source " + shellQuote(z.script)
+}
+
+// zshLegacyLines are the lines that complete's installer adds to .zshrc.
+func zshLegacyLines(bin string, programs []string) []string {
+	lines := make([]string, len(programs))
+	for i, program := range programs {
+		lines[i] = fmt.Sprintf("complete -o nospace -C %s %s", bin, program)
+	}
+	return lines
+}
+
+// fishShell completes each program with a file in the completions directory
+// of fish, which fish loads when the program is first completed.
+type fishShell struct {
+	dir string
+}
+
+func (fishShell) name() string { return "fish" }
+
+func (f fishShell) install(bin string, programs []string) error {
+	for _, program := range programs {
+		err := writeScript(f.file(program), fishScript, bin,
+			[]string{program})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (f fishShell) uninstall(bin string, programs []string) error {
+	for _, program := range programs {
+		err := os.Remove(f.file(program))
+		if err != nil && !os.IsNotExist(err) {
+			return err
+		}
+	}
+	return nil
+}
+
+func (f fishShell) installed(programs []string) []string {
+	var installed []string
+	for _, program := range programs {
+		if fileExists(f.file(program)) {
+			installed = append(installed, program)
+		}
+	}
+	return installed
+}
+
+func (f fishShell) file(program string) string {
+	return filepath.Join(f.dir, program+".fish")
+}
+
+// zshScript completes the programs with the candidates of the binary, which
+// are listed by _describe under the group that precedes each of them. Like
+// bashcompinit, it passes only the words of the command being completed, not
+// the whole buffer, which may hold several commands.
+var zshScript = template.Must(template.New("zsh").Parse(
+	`# Completion of {{.Programs}} by complete-factom-cli.
+_complete_factom_cli() {
+	local line group cmdline=${(j: :)words[1,CURRENT]}
+	local -i point
+	local -a order candidates
+	local -A items
+	point=$(( ${#${(j: :)words[1,CURRENT-1]}} + 1 + $#QIPREFIX + $#IPREFIX + $#PREFIX ))
+	[[ $PREFIX == -*=* ]] && compset -P '*='
+	for line in "${(@f)$(COMP_SHELL=zsh COMP_LINE=$cmdline COMP_POINT=$point {{.Bin}} 2>/dev/null)}"; do
+		[[ -n $line ]] || continue
+		group=${line%%$'\t'*}
+		(( ${+items[$group]} )) || order+=("$group")
+		items[$group]+=${line#*$'\t'}$'\n'
+	done
+	for group in $order; do
+		candidates=("${(@f)${items[$group]%$'\n'}}")
+		_describe -t ${group// /-} $group candidates
+	done
+}
+(( $+functions[compdef] )) || { autoload -Uz compinit && compinit; }
+compdef _complete_factom_cli {{.Programs}}
+`))
+
+// fishScript completes the programs with the candidates of the binary,
+// which are described as complete -a expects.
+var fishScript = template.Must(template.New("fish").Parse(
+	`# Completion of {{.Programs}} by complete-factom-cli.
+function __complete_factom_cli
+    set -lx COMP_SHELL fish
+    set -lx COMP_LINE (commandline -cp)
+    set -lx COMP_POINT (string length -- "$COMP_LINE")
+    {{.Bin}} 2>/dev/null
+end
+{{range .List}}complete -c {{.}} -e
+complete -c {{.}} -f -a '(__complete_factom_cli)'
+{{end}}`))
+
+// writeScript writes the script that completes programs with bin to name.
+func writeScript(name string, script *template.Template, bin string,
+	programs []string) error {
+	var buf bytes.Buffer
+	err := script.Execute(&buf, struct {
+		Bin, Programs string
+		List          []string
+	}{shellQuote(bin), strings.Join(programs, " "), programs})
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(name), 0755); err != nil {
+		return err
+	}
+	return ioutil.WriteFile(name, buf.Bytes(), 0644)
+}
+
+// completedPrograms returns the programs that a line starting with prefix
+// completes as its last word.
+func completedPrograms(lines []string, prefix string,
+	programs []string) []string {
+	var completed []string
+	for _, program := range programs {
+		for _, line := range lines {
+			words := strings.Fields(line)
+			if strings.HasPrefix(line, prefix) && len(words) > 0 &&
+				words[len(words)-1] == program {
+				completed = append(completed, program)
+				break
+			}
+		}
+	}
+	return completed
+}
+
+func containsString(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
+
+// readLines returns the lines of the file name, or nil if it can not be
+// read.
+func readLines(name string) []string {
+	data, err := ioutil.ReadFile(name)
+	if err != nil {
+		return nil
+	}
+	return strings.Split(string(data), "\n")
+}
+
+// addLines appends the lines that are not yet in the file name to it.
+func addLines(name string, lines []string) error {
+	data, err := ioutil.ReadFile(name)
+	if err != nil {
+		return err
+	}
+	existing := make(map[string]bool)
+	for _, line := range strings.Split(string(data), "\n") {
+		existing[line] = true
+	}
+	var add bytes.Buffer
+	for _, line := range lines {
+		if !existing[line] {
+			fmt.Fprintln(&add, line)
+		}
+	}
+	if add.Len() == 0 {
+		return nil
+	}
+	if len(data) > 0 && !bytes.HasSuffix(data, []byte("\n")) {
+		data = append(data, '\n')
+	}
+	return writeFileMode(name, append(data, add.Bytes()...))
+}
+
+// removeLines removes the lines from the file name.
+func removeLines(name string, lines []string) error {
+	data, err := ioutil.ReadFile(name)
+	if err != nil {
+		return err
+	}
+	remove := make(map[string]bool)
+	for _, line := range lines {
+		remove[line] = true
+	}
+	var kept []string
+	for _, line := range strings.Split(string(data), "\n") {
+		if !remove[line] {
+			kept = append(kept, line)
+		}
+	}
+	result := strings.Join(kept, "\n")
+	if result == string(data) {
+		return nil
+	}
+	return writeFileMode(name, []byte(result))
+}
+
+// writeFileMode replaces the content of the existing file name, keeping its
+// mode.
+func writeFileMode(name string, data []byte) error {
+	info, err := os.Stat(name)
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(name, data, info.Mode())
+}
+
+func fileExists(name string) bool {
+	_, err := os.Stat(name)
+	return err == nil
+}
@@ -0,0 +1,111 @@
+package factomcli
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// TestShells checks that the completion is installed into and uninstalled
+// from the configuration of bash, zsh and fish, and that installing twice
+// changes nothing.
+func TestShells(t *testing.T) {
+	home, err := ioutil.TempDir("", "complete-factom-cli")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(home)
+	defer os.Setenv("XDG_CONFIG_HOME", os.Getenv("XDG_CONFIG_HOME"))
+	os.Unsetenv("XDG_CONFIG_HOME")
+
+	const bin = "/opt/factom/complete-factom-cli"
+	programs := []string{"factom-cli", "factomd"}
+	bashrc := filepath.Join(home, ".bashrc")
+	zshrc := filepath.Join(home, ".zshrc")
+	writeTestFile(t, bashrc, "alias ll='ls -l'\n")
+	writeTestFile(t, zshrc, "setopt autocd\n"+
+		"complete -o nospace -C "+bin+" factom-cli\n")
+	if err := os.MkdirAll(filepath.Join(home, ".config", "fish"),
+		0755); err != nil {
+		t.Fatal(err)
+	}
+
+	shells := installedShells(home)
+	if len(shells) != 3 {
+		t.Fatalf("got %d shells, want 3", len(shells))
+	}
+	for i := 0; i < 2; i++ {
+		for _, sh := range shells {
+			if err := sh.install(bin, programs); err != nil {
+				t.Fatalf("install %s: %v", sh.name(), err)
+			}
+		}
+	}
+
+	script := filepath.Join(home, ".factom", "complete-factom-cli",
+		"complete.zsh")
+	fishFile := filepath.Join(home, ".config", "fish", "completions",
+		"factomd.fish")
+	files := []struct {
+		name string
+		want string
+	}{
+		{bashrc, "alias ll='ls -l'\n" +
+			"complete -C " + bin + " factom-cli\n" +
+			"complete -C " + bin + " factomd\n"},
+		{zshrc, "setopt autocd\n" + "source " + script + "\n"},
+	}
+	for _, file := range files {
+		if got := readTestFile(t, file.name); got != file.want {
+			t.Errorf("%s: got %q, want %q", file.name, got, file.want)
+		}
+	}
+	zsh := readTestFile(t, script)
+	if !strings.Contains(zsh, "COMP_SHELL=zsh COMP_LINE=$cmdline") ||
+		!strings.Contains(zsh, "cmdline=${(j: :)words[1,CURRENT]}") ||
+		!strings.Contains(zsh,
+			"compdef _complete_factom_cli factom-cli factomd\n") {
+		t.Errorf("zsh script:\n%s", zsh)
+	}
+	fish := readTestFile(t, fishFile)
+	if !strings.Contains(fish,
+		"complete -c factomd -f -a '(__complete_factom_cli)'\n") ||
+		strings.Contains(fish, "-c factom-cli") {
+		t.Errorf("fish script:\n%s", fish)
+	}
+
+	for _, sh := range shells {
+		if err := sh.uninstall(bin, programs); err != nil {
+			t.Fatalf("uninstall %s: %v", sh.name(), err)
+		}
+	}
+	if got, want := readTestFile(t, bashrc), "alias ll='ls -l'\n"; got != want {
+		t.Errorf("%s: got %q, want %q", bashrc, got, want)
+	}
+	if got, want := readTestFile(t, zshrc), "setopt autocd\n"; got != want {
+		t.Errorf("%s: got %q, want %q", zshrc, got, want)
+	}
+	for _, name := range []string{script, fishFile} {
+		if fileExists(name) {
+			t.Errorf("%s was not removed", name)
+		}
+	}
+}
+
+func writeTestFile(t *testing.T, name, content string) {
+	t.Helper()
+	if err := ioutil.WriteFile(name, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func readTestFile(t *testing.T, name string) string {
+	t.Helper()
+	data, err := ioutil.ReadFile(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(data)
+}
@@ -0,0 +1,236 @@
+package factomcli
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/posener/complete"
+)
+
+//go:generate go run ./internal/specgen -o spec_gen.go -var factomCLISpecs testdata/factom-cli
+//go:generate go run ./internal/specgen -o spec_factomd_gen.go -program factomd -var factomdSpecs testdata/factomd
+//go:generate go run ./internal/specgen -o spec_walletd_gen.go -program factom-walletd -var walletdSpecs testdata/factom-walletd
+
+// commandSpec describes a command as the usage text of its program does.
+// The specs are generated from the usage text by internal/specgen.
+type commandSpec struct {
+	// Path is the space separated list of sub command names below the
+	// program. It is empty for the program itself.
+	Path        string
+	Usage       string
+	Description string
+	Flags       []flagSpec
+	Args        []argSpec
+	// Exclusive lists groups of alternatives of which only one may be
+	// used, as in flagRules.
+	Exclusive [][]string
+}
+
+// flagSpec describes a flag of a command.
+type flagSpec struct {
+	Name string
+	// Arg names the value of the flag. It is empty for boolean flags.
+	Arg         string
+	Repeatable  bool
+	Description string
+}
+
+// argSpec describes a positional argument of a command.
+type argSpec struct {
+	// Kind is the name of the argument in the usage text, such as
+	// ECADDRESS.
+	Kind     string
+	Optional bool
+	Variadic bool
+}
+
+// selectSpecs returns the specs of the version in specs that describes the
+// given version of a program, as selected by selectVersion.
+func selectSpecs(specs map[string][]commandSpec,
+	version string) []commandSpec {
+	versions := make([]string, 0, len(specs))
+	for v := range specs {
+		versions = append(versions, v)
+	}
+	return specs[selectVersion(versions, version)]
+}
+
+// numericArgs are the names that flag.PrintDefaults gives to the values of
+// numeric flags. There is nothing to predict for them.
+var numericArgs = map[string]bool{
+	"int":      true,
+	"int64":    true,
+	"uint":     true,
+	"uint64":   true,
+	"float":    true,
+	"duration": true,
+}
+
+// subcommandKind is the kind of an argument that names a sub command of the
+// program, as in help [SUBCOMMAND].
+const subcommandKind = "SUBCOMMAND"
+
+// predictorTable holds the predictors of the flags and positional arguments
+// of a program by flag name or argument kind. A key may be prefixed by the
+// path of a command, as in "addtxinput AMOUNT", to override the predictor
+// for that command only.
+type predictorTable map[string]complete.Predictor
+
+// lookup returns the predictor of the flag or argument kind key of the
+// command at path.
+func (t predictorTable) lookup(path, key string) (complete.Predictor, bool) {
+	if len(path) > 0 {
+		if p, ok := t[path+" "+key]; ok {
+			return p, true
+		}
+	}
+	p, ok := t[key]
+	return p, ok
+}
+
+// commandTree builds the completion command tree of a program from the
+// specs of its commands.
+type commandTree struct {
+	specs      map[string]commandSpec
+	children   map[string][]string
+	predictors predictorTable
+}
+
+func newCommandTree(specs []commandSpec,
+	predictors predictorTable) commandTree {
+	t := commandTree{
+		specs:      make(map[string]commandSpec),
+		children:   make(map[string][]string),
+		predictors: predictors,
+	}
+	for _, spec := range specs {
+		t.specs[spec.Path] = spec
+		// Sub commands may be listed before their parent or without
+		// one.
+		for path := spec.Path; len(path) > 0; {
+			parent, name := "", path
+			if i := strings.LastIndex(path, " "); i >= 0 {
+				parent, name = path[:i], path[i+1:]
+			}
+			if !t.hasChild(parent, name) {
+				t.children[parent] = append(t.children[parent], name)
+			}
+			path = parent
+		}
+	}
+	return t
+}
+
+func (t commandTree) hasChild(path, name string) bool {
+	for _, child := range t.children[path] {
+		if child == name {
+			return true
+		}
+	}
+	return false
+}
+
+// buildCommand returns the completion command tree of the program and the
+// rules of its flags. The flag rules in extra are added to those of the
+// specs.
+func buildCommand(specs []commandSpec, predictors predictorTable,
+	extra commandRules) (complete.Command, commandRules) {
+	t := newCommandTree(specs, predictors)
+	rules := make(commandRules)
+	return t.build("", extra, rules), rules
+}
+
+// build returns the command at path and adds the rules of it and its sub
+// commands to rules.
+func (t commandTree) build(path string, extra,
+	rules commandRules) complete.Command {
+	spec := t.specs[path]
+	var cmd complete.Command
+	for _, f := range spec.Flags {
+		if cmd.Flags == nil {
+			cmd.Flags = make(complete.Flags)
+		}
+		predictor, ok := t.flagPredictor(path, f)
+		if !ok {
+			complete.Log("error: %q: no predictor for %v %v", path,
+				f.Name, f.Arg)
+		}
+		cmd.Flags[f.Name] = predictor
+	}
+	for _, name := range t.children[path] {
+		if cmd.Sub == nil {
+			cmd.Sub = make(complete.Commands)
+		}
+		cmd.Sub[name] = t.build(strings.TrimSpace(path+" "+name),
+			extra, rules)
+	}
+
+	switch {
+	case len(spec.Args) > 0:
+		args := make([]complete.Predictor, len(spec.Args))
+		for i, a := range spec.Args {
+			predictor, ok := t.argPredictor(path, a.Kind)
+			if !ok {
+				complete.Log("error: %q: no predictor for %v", path,
+					a.Kind)
+			}
+			args[i] = predictor
+		}
+		cmd.Args = positional{
+			flags:    cmd.Flags,
+			args:     args,
+			variadic: spec.Args[len(spec.Args)-1].Variadic,
+		}
+	case cmd.Sub == nil:
+		cmd.Args = complete.PredictNothing
+	}
+
+	r := flagRules{
+		exclusive: append(append([][]string{}, spec.Exclusive...),
+			extra[path].exclusive...),
+	}
+	for _, f := range spec.Flags {
+		if f.Repeatable {
+			r.repeatable = append(r.repeatable, f.Name)
+		}
+	}
+	r.repeatable = append(r.repeatable, extra[path].repeatable...)
+	r.ends = extra[path].ends
+	if len(r.repeatable) > 0 || len(r.exclusive) > 0 || r.ends {
+		for _, a := range spec.Args {
+			r.args = append(r.args, a.Kind)
+		}
+		rules[path] = r
+	}
+	return cmd
+}
+
+// flagPredictor returns the predictor of the flag f of the command at path.
+// Flags without a predictor accept any value. Numeric flags need none.
+func (t commandTree) flagPredictor(path string,
+	f flagSpec) (complete.Predictor, bool) {
+	if len(f.Arg) == 0 {
+		// A boolean flag.
+		return complete.PredictNothing, true
+	}
+	if p, ok := t.predictors.lookup(path, f.Name); ok {
+		return p, true
+	}
+	return complete.PredictAnything, numericArgs[f.Arg]
+}
+
+// argPredictor returns the predictor of the positional arguments of the
+// given kind of the command at path. Arguments without a predictor accept
+// anything.
+func (t commandTree) argPredictor(path, kind string) (complete.Predictor,
+	bool) {
+	if kind == subcommandKind {
+		names := append([]string{}, t.children[""]...)
+		sort.Strings(names)
+		return predictSet(names...), true
+	}
+	if p, ok := t.predictors.lookup(path, kind); ok {
+		return p, true
+	}
+	return complete.PredictAnything, false
+}
@@ -0,0 +1,66 @@
+// Code generated by specgen from testdata/factomd. DO NOT EDIT.
+
+package factomcli
+
+var factomdSpecs = map[string][]commandSpec{
+	"v6.1.0": {
+		{
+			Path: "",
+			Flags: []flagSpec{
+				{Name: "-balancehash", Description: "If false, then don't pass around balance hashes (default true)"},
+				{Name: "-blktime", Arg: "int", Description: "Seconds per block.  Production is 600."},
+				{Name: "-broadcastnum", Arg: "int", Description: "Number of peers to broadcast to in the peer to peer networking (default 16)"},
+				{Name: "-checkheads", Description: "Enables checking chain heads on boot (default true)"},
+				{Name: "-clonedb", Arg: "string", Description: "Override the main node and use this database for the clones in a Network."},
+				{Name: "-config", Arg: "string", Description: "Override the config file location (factomd.conf)"},
+				{Name: "-controlpanelport", Arg: "int", Description: "Port for control panel webserver;  Default 8090"},
+				{Name: "-controlpanelsetting", Arg: "string", Description: "Can set to 'disabled', 'readonly', or 'readwrite' to overwrite config file"},
+				{Name: "-count", Arg: "int", Description: "The number of nodes to generate (default 1)"},
+				{Name: "-customnet", Arg: "string", Description: "This string specifies a custom blockchain network ID."},
+				{Name: "-db", Arg: "string", Description: "Override the Database in the Config file and use this Database implementation. Options Map, LDB, or Bolt"},
+				{Name: "-debugconsole", Arg: "string", Description: "Enable DebugConsole on port. localhost:8093 open 8093 and spawns a telnet console, remotehost:8093 open 8093"},
+				{Name: "-debuglog", Arg: "string", Description: "regex to pick which logs to save"},
+				{Name: "-drop", Arg: "int", Description: "Number of messages to drop out of every thousand"},
+				{Name: "-enablenet", Description: "Enable or disable networking (default true)"},
+				{Name: "-exclusive", Description: "If true, we only dial out to special/trusted peers."},
+				{Name: "-exclusive_in", Description: "If true, we only dial out to special/trusted peers and no incoming connections are accepted."},
+				{Name: "-factomhome", Arg: "string", Description: "Set the Factom home directory. The .factom folder will be placed here if set, otherwise it will default to $HOME"},
+				{Name: "-fast", Description: "If true, Factomd will fast-boot from a file. (default true)"},
+				{Name: "-fastlocation", Arg: "string", Description: "Directory to put the Fast-boot file in."},
+				{Name: "-faulttimeout", Arg: "int", Description: "Seconds before considering Federated servers at-fault. Default is 120. (default 120)"},
+				{Name: "-fnet", Arg: "string", Description: "Read the given file to build the network connections"},
+				{Name: "-follower", Description: "If true, force node to be a follower.  Only used when replaying a journal."},
+				{Name: "-journal", Arg: "string", Description: "Rerun a Journal of messages"},
+				{Name: "-journaling", Description: "Write a journal of all messages received. Default is off."},
+				{Name: "-keepmismatch", Description: "If true, do not discard DBStates even when a majority of DBSignatures have a different hash"},
+				{Name: "-leader", Description: "If true, force node to be a leader.  Only used when replaying a journal. (default true)"},
+				{Name: "-logPort", Arg: "string", Description: "Port for pprof logging (default \"6060\")"},
+				{Name: "-logjson", Description: "Use to set logging to use a json formatting"},
+				{Name: "-loglvl", Arg: "string", Description: "Set log level to either: none, debug, info, warning, error, fatal or panic (default \"none\")"},
+				{Name: "-net", Arg: "string", Description: "The default algorithm to build the network connections (default \"tree\")"},
+				{Name: "-network", Arg: "string", Description: "Network to join: MAIN, TEST, LOCAL or CUSTOM"},
+				{Name: "-node", Arg: "int", Description: "Node Number the simulator will set as the focus"},
+				{Name: "-nodename", Arg: "string", Description: "Assign a name to the node"},
+				{Name: "-p2pPort", Arg: "string", Description: "Port to listen for peers on. (default \"8108\")"},
+				{Name: "-peers", Arg: "string", Description: "Array of peer addresses."},
+				{Name: "-plugin", Arg: "string", Description: "Input the path to any plugin binaries"},
+				{Name: "-port", Arg: "int", Description: "Port where we serve WSAPI;  default 8088"},
+				{Name: "-prefix", Arg: "string", Description: "Prefix the Factom Node Names with this value; used to create leaderless networks."},
+				{Name: "-rpcpass", Arg: "string", Description: "Password to protect factomd local API. Ignored if rpcuser is blank"},
+				{Name: "-rpcuser", Arg: "string", Description: "Username to protect factomd local API with simple HTTP authentication"},
+				{Name: "-runtimeLog", Description: "If true, maintain runtime logs of messages passed."},
+				{Name: "-selfaddr", Arg: "string", Description: "comma separated IPAddresses and DNS names of this factomd to use when creating a cert file"},
+				{Name: "-sim_stdin", Description: "If true, sim control reads from stdin. (default true)"},
+				{Name: "-startdelay", Arg: "int", Description: "Delay to start processing messages, in seconds (default 10)"},
+				{Name: "-stderrlog", Arg: "string", Description: "Log stderr to a file, optionally the same file as stdout"},
+				{Name: "-stdoutlog", Arg: "string", Description: "Log stdout to a file"},
+				{Name: "-timedelta", Arg: "int", Description: "Maximum timeDelta in milliseconds to offset each node.  Simulates deltas in system clocks over a network."},
+				{Name: "-tls", Description: "Set to true to require encrypted connections to factomd API and Control Panel"},
+				{Name: "-tormanage", Description: "Use torrent dbstate manager. Must have plugin binary installed and in $PATH"},
+				{Name: "-torupload", Description: "Be a torrent uploader"},
+				{Name: "-waitentries", Description: "Wait for Entries to be validated prior to execution of messages"},
+				{Name: "-wrproc", Description: "Write processed blocks to temporary debug file (default true)"},
+			},
+		},
+	},
+}
@@ -0,0 +1,1689 @@
+// Code generated by specgen from testdata/factom-cli. DO NOT EDIT.
+
+package factomcli
+
+var factomCLISpecs = map[string][]commandSpec{
+	"v2.2.0": {
+		{
+			Path:  "",
+			Usage: "factom-cli [OPTIONS] SUBCOMMAND [OPTIONS]",
+			Flags: []flagSpec{
+				{Name: "-factomdcert", Arg: "string", Description: "path to the factomd TLS certificate (default \"~/.factom/m2/factomdAPIpub.cert\")"},
+				{Name: "-factomdpassword", Arg: "string", Description: "password for API connections to factomd"},
+				{Name: "-factomdtls", Description: "set to true to use TLS when connecting to factomd"},
+				{Name: "-factomduser", Arg: "string", Description: "username for API connections to factomd"},
+				{Name: "-s", Arg: "string", Description: "IPAddr:port# of factomd API to use to access blockchain (default \"localhost:8088\")"},
+				{Name: "-w", Arg: "string", Description: "IPAddr:port# of factom-walletd API to use to create transactions (default \"localhost:8089\")"},
+				{Name: "-walletcert", Arg: "string", Description: "path to the factom-walletd TLS certificate (default \"~/.factom/walletAPIpub.cert\")"},
+				{Name: "-walletpassword", Arg: "string", Description: "password for API connections to factom-walletd"},
+				{Name: "-wallettls", Description: "set to true to use TLS when connecting to factom-walletd"},
+				{Name: "-walletuser", Arg: "string", Description: "username for API connections to factom-walletd"},
+			},
+		},
+		{
+			Path:        "addchain",
+			Usage:       "factom-cli addchain [-fq] [-n NAME1 -n NAME2 -h HEXNAME3 ] [-CET] ECADDRESS <STDIN>",
+			Description: "Create a new Factom Chain. Read data for the First Entry from stdin. Use the Entry Credits from the specified address.",
+			Flags: []flagSpec{
+				{Name: "-f"},
+				{Name: "-q"},
+				{Name: "-n", Arg: "NAME", Repeatable: true},
+				{Name: "-h", Arg: "HEXNAME", Repeatable: true},
+				{Name: "-C"},
+				{Name: "-E"},
+				{Name: "-T"},
+			},
+			Args: []argSpec{
+				{Kind: "ECADDRESS"},
+			},
+		},
+		{
+			Path:        "addentry",
+			Usage:       "factom-cli addentry [-fq] [-n NAME1 -h HEXNAME2 ...|-c CHAINID] [-e EXTID1 -e EXTID2 -x HEXEXTID ...] [-CET] ECADDRESS <STDIN>",
+			Description: "Create a new Factom Entry. Read data for the Entry from stdin. Use the Entry Credits from the specified address.",
+			Flags: []flagSpec{
+				{Name: "-f"},
+				{Name: "-q"},
+				{Name: "-n", Arg: "NAME", Repeatable: true},
+				{Name: "-h", Arg: "HEXNAME", Repeatable: true},
+				{Name: "-c", Arg: "CHAINID"},
+				{Name: "-e", Arg: "EXTID", Repeatable: true},
+				{Name: "-x", Arg: "HEXEXTID", Repeatable: true},
+				{Name: "-C"},
+				{Name: "-E"},
+				{Name: "-T"},
+			},
+			Args: []argSpec{
+				{Kind: "ECADDRESS"},
+			},
+			Exclusive: [][]string{
+				{"-n -h", "-c"},
+			},
+		},
+		{
+			Path:        "addtxecoutput",
+			Usage:       "factom-cli addtxecoutput [-rq] TXNAME ADDRESS AMOUNT",
+			Description: "Add an Entry Credit output to a transaction in the wallet.",
+			Flags: []flagSpec{
+				{Name: "-r"},
+				{Name: "-q"},
+			},
+			Args: []argSpec{
+				{Kind: "TXNAME"},
+				{Kind: "ADDRESS"},
+				{Kind: "AMOUNT"},
+			},
+		},
+		{
+			Path:        "addtxfee",
+			Usage:       "factom-cli addtxfee [-q] TXNAME ADDRESS",
+			Description: "Add the transaction fee to an input of a transaction in the wallet.",
+			Flags: []flagSpec{
+				{Name: "-q"},
+			},
+			Args: []argSpec{
+				{Kind: "TXNAME"},
+				{Kind: "ADDRESS"},
+			},
+		},
+		{
+			Path:        "addtxinput",
+			Usage:       "factom-cli addtxinput [-q] TXNAME ADDRESS AMOUNT",
+			Description: "Add a Factoid input to a transaction in the wallet.",
+			Flags: []flagSpec{
+				{Name: "-q"},
+			},
+			Args: []argSpec{
+				{Kind: "TXNAME"},
+				{Kind: "ADDRESS"},
+				{Kind: "AMOUNT"},
+			},
+		},
+		{
+			Path:        "addtxoutput",
+			Usage:       "factom-cli addtxoutput [-rq] TXNAME ADDRESS AMOUNT",
+			Description: "Add a Factoid output to a transaction in the wallet.",
+			Flags: []flagSpec{
+				{Name: "-r"},
+				{Name: "-q"},
+			},
+			Args: []argSpec{
+				{Kind: "TXNAME"},
+				{Kind: "ADDRESS"},
+				{Kind: "AMOUNT"},
+			},
+		},
+		{
+			Path:        "backupwallet",
+			Usage:       "factom-cli backupwallet",
+			Description: "Backup the wallet seed and all imported addresses.",
+		},
+		{
+			Path:        "balance",
+			Usage:       "factom-cli balance [-r] ADDRESS",
+			Description: "If this is an EC Address, returns the number of Entry Credits. If this is a Factoid Address, returns the Factoid balance.",
+			Flags: []flagSpec{
+				{Name: "-r"},
+			},
+			Args: []argSpec{
+				{Kind: "ADDRESS"},
+			},
+		},
+		{
+			Path:        "buyexactec",
+			Usage:       "factom-cli buyexactec [-fqrT] FCTADDRESS ECADDRESS ECAMOUNT",
+			Description: "Buy the specified number of Entry Credits with Factoids.",
+			Flags: []flagSpec{
+				{Name: "-f"},
+				{Name: "-q"},
+				{Name: "-r"},
+				{Name: "-T"},
+			},
+			Args: []argSpec{
+				{Kind: "FCTADDRESS"},
+				{Kind: "ECADDRESS"},
+				{Kind: "ECAMOUNT"},
+			},
+		},
+		{
+			Path:        "composechain",
+			Usage:       "factom-cli composechain [-f] [-n NAME1 -n NAME2 -h HEXNAME3 ] ECADDRESS <STDIN>",
+			Description: "Create API calls to create a new Factom Chain. Read data for the First Entry from stdin.",
+			Flags: []flagSpec{
+				{Name: "-f"},
+				{Name: "-n", Arg: "NAME", Repeatable: true},
+				{Name: "-h", Arg: "HEXNAME", Repeatable: true},
+			},
+			Args: []argSpec{
+				{Kind: "ECADDRESS"},
+			},
+		},
+		{
+			Path:        "composeentry",
+			Usage:       "factom-cli composeentry [-f] [-n NAME1 -h HEXNAME2 ...|-c CHAINID]  [-e EXTID1 -e EXTID2 -x HEXEXTID ...] ECADDRESS <STDIN>",
+			Description: "Create API calls to create a new Factom Entry. Read data for the Entry from stdin.",
+			Flags: []flagSpec{
+				{Name: "-f"},
+				{Name: "-n", Arg: "NAME", Repeatable: true},
+				{Name: "-h", Arg: "HEXNAME", Repeatable: true},
+				{Name: "-c", Arg: "CHAINID"},
+				{Name: "-e", Arg: "EXTID", Repeatable: true},
+				{Name: "-x", Arg: "HEXEXTID", Repeatable: true},
+			},
+			Args: []argSpec{
+				{Kind: "ECADDRESS"},
+			},
+			Exclusive: [][]string{
+				{"-n -h", "-c"},
+			},
+		},
+		{
+			Path:        "composetx",
+			Usage:       "factom-cli composetx TXNAME",
+			Description: "Compose a wallet transaction into a JSON RPC object.",
+			Args: []argSpec{
+				{Kind: "TXNAME"},
+			},
+		},
+		{
+			Path:        "ecrate",
+			Usage:       "factom-cli ecrate",
+			Description: "Show the number of Factoshis needed to buy 1 Entry Credit.",
+		},
+		{
+			Path:        "exportaddresses",
+			Usage:       "factom-cli exportaddresses",
+			Description: "Export the secret keys of all addresses in the wallet.",
+		},
+		{
+			Path:        "get",
+			Usage:       "factom-cli get allentries|chainhead|dblock|eblock|entry|firstentry|head|heights|walletheight|pendingentries|pendingtransactions|raw|dbheight|abheight|fbheight|ecbheight",
+			Description: "Get data from the Factom blockchain.",
+		},
+		{
+			Path:        "get abheight",
+			Usage:       "factom-cli get abheight HEIGHT -r (to suppress Raw Data)",
+			Description: "Get the Admin Block at the specified height.",
+			Flags: []flagSpec{
+				{Name: "-r"},
+			},
+			Args: []argSpec{
+				{Kind: "HEIGHT"},
+			},
+		},
+		{
+			Path:        "get allentries",
+			Usage:       "factom-cli get allentries [-n NAME1 -h HEXNAME2 ...|CHAINID] [-E]",
+			Description: "Get all of the Entries in a Chain.",
+			Flags: []flagSpec{
+				{Name: "-n", Arg: "NAME", Repeatable: true},
+				{Name: "-h", Arg: "HEXNAME", Repeatable: true},
+				{Name: "-E"},
+			},
+			Args: []argSpec{
+				{Kind: "CHAINID", Optional: true},
+			},
+			Exclusive: [][]string{
+				{"-n -h", "CHAINID"},
+			},
+		},
+		{
+			Path:        "get chainhead",
+			Usage:       "factom-cli get chainhead [-n NAME1 -h HEXNAME2 ...|CHAINID] [-K]",
+			Description: "Get the latest Entry Block of a Chain.",
+			Flags: []flagSpec{
+				{Name: "-n", Arg: "NAME", Repeatable: true},
+				{Name: "-h", Arg: "HEXNAME", Repeatable: true},
+				{Name: "-K"},
+			},
+			Args: []argSpec{
+				{Kind: "CHAINID", Optional: true},
+			},
+			Exclusive: [][]string{
+				{"-n -h", "CHAINID"},
+			},
+		},
+		{
+			Path:        "get dbheight",
+			Usage:       "factom-cli get dbheight HEIGHT -r (to suppress Raw Data)",
+			Description: "Get the Directory Block at the specified height.",
+			Flags: []flagSpec{
+				{Name: "-r"},
+			},
+			Args: []argSpec{
+				{Kind: "HEIGHT"},
+			},
+		},
+		{
+			Path:        "get dblock",
+			Usage:       "factom-cli get dblock KEYMR",
+			Description: "Get a Directory Block by its KeyMR.",
+			Args: []argSpec{
+				{Kind: "KEYMR"},
+			},
+		},
+		{
+			Path:        "get eblock",
+			Usage:       "factom-cli get eblock KEYMR",
+			Description: "Get an Entry Block by its KeyMR.",
+			Args: []argSpec{
+				{Kind: "KEYMR"},
+			},
+		},
+		{
+			Path:        "get ecbheight",
+			Usage:       "factom-cli get ecbheight HEIGHT -r (to suppress Raw Data)",
+			Description: "Get the Entry Credit Block at the specified height.",
+			Flags: []flagSpec{
+				{Name: "-r"},
+			},
+			Args: []argSpec{
+				{Kind: "HEIGHT"},
+			},
+		},
+		{
+			Path:        "get entry",
+			Usage:       "factom-cli get entry HASH",
+			Description: "Get an Entry by its hash.",
+			Args: []argSpec{
+				{Kind: "HASH"},
+			},
+		},
+		{
+			Path:        "get fbheight",
+			Usage:       "factom-cli get fbheight HEIGHT -r (to suppress Raw Data)",
+			Description: "Get the Factoid Block at the specified height.",
+			Flags: []flagSpec{
+				{Name: "-r"},
+			},
+			Args: []argSpec{
+				{Kind: "HEIGHT"},
+			},
+		},
+		{
+			Path:        "get firstentry",
+			Usage:       "factom-cli get firstentry [-n NAME1 -h HEXNAME2 ...|CHAINID] [-E]",
+			Description: "Get the first Entry of a Chain.",
+			Flags: []flagSpec{
+				{Name: "-n", Arg: "NAME", Repeatable: true},
+				{Name: "-h", Arg: "HEXNAME", Repeatable: true},
+				{Name: "-E"},
+			},
+			Args: []argSpec{
+				{Kind: "CHAINID", Optional: true},
+			},
+			Exclusive: [][]string{
+				{"-n -h", "CHAINID"},
+			},
+		},
+		{
+			Path:        "get head",
+			Usage:       "factom-cli get head [-K]",
+			Description: "Get the latest Directory Block.",
+			Flags: []flagSpec{
+				{Name: "-K"},
+			},
+		},
+		{
+			Path:        "get heights",
+			Usage:       "factom-cli get heights",
+			Description: "Get the current heights of the blockchain.",
+		},
+		{
+			Path:        "get pendingentries",
+			Usage:       "factom-cli get pendingentries [-E]",
+			Description: "Get the Entries that are not yet in a block.",
+			Flags: []flagSpec{
+				{Name: "-E"},
+			},
+		},
+		{
+			Path:        "get pendingtransactions",
+			Usage:       "factom-cli get pendingtransactions [-T]",
+			Description: "Get the transactions that are not yet in a block.",
+			Flags: []flagSpec{
+				{Name: "-T"},
+			},
+		},
+		{
+			Path:        "get raw",
+			Usage:       "factom-cli get raw HASH",
+			Description: "Get the raw data of a block or Entry by its hash.",
+			Args: []argSpec{
+				{Kind: "HASH"},
+			},
+		},
+		{
+			Path:        "get walletheight",
+			Usage:       "factom-cli get walletheight",
+			Description: "Get the height up to which the wallet has synced.",
+		},
+		{
+			Path:        "help",
+			Usage:       "factom-cli help [SUBCOMMAND]",
+			Description: "Print the help of a subcommand.",
+			Args: []argSpec{
+				{Kind: "SUBCOMMAND", Optional: true},
+			},
+		},
+		{
+			Path:        "importaddress",
+			Usage:       "factom-cli importaddress ADDRESS [ADDRESS...]",
+			Description: "Import one or more secret keys into the wallet.",
+			Args: []argSpec{
+				{Kind: "ADDRESS", Variadic: true},
+			},
+		},
+		{
+			Path:        "importkoinify",
+			Usage:       "factom-cli importkoinify '12WORDS'",
+			Description: "Import a Koinify crowd sale address from its 12 word mnemonic.",
+			Args: []argSpec{
+				{Kind: "12WORDS"},
+			},
+		},
+		{
+			Path:        "listaddresses",
+			Usage:       "factom-cli listaddresses",
+			Description: "List the addresses in the wallet and their balances.",
+		},
+		{
+			Path:        "listtxs",
+			Usage:       "factom-cli listtxs [address|all|id|name|tmp|range]",
+			Description: "List transactions from the wallet or the blockchain.",
+			Flags: []flagSpec{
+				{Name: "-T"},
+			},
+		},
+		{
+			Path:        "listtxs address",
+			Usage:       "factom-cli listtxs address [-T] ECADDRESS|FCTADDRESS",
+			Description: "List the transactions of an address.",
+			Flags: []flagSpec{
+				{Name: "-T"},
+			},
+			Args: []argSpec{
+				{Kind: "ECADDRESS|FCTADDRESS"},
+			},
+		},
+		{
+			Path:        "listtxs all",
+			Usage:       "factom-cli listtxs [all] [-T]",
+			Description: "List all transactions.",
+			Flags: []flagSpec{
+				{Name: "-T"},
+			},
+		},
+		{
+			Path:        "listtxs id",
+			Usage:       "factom-cli listtxs id TXID",
+			Description: "List a transaction by its TXID.",
+			Args: []argSpec{
+				{Kind: "TXID"},
+			},
+		},
+		{
+			Path:        "listtxs name",
+			Usage:       "factom-cli listtxs name TXNAME",
+			Description: "List a transaction in the wallet by its name.",
+			Args: []argSpec{
+				{Kind: "TXNAME"},
+			},
+		},
+		{
+			Path:        "listtxs range",
+			Usage:       "factom-cli listtxs range [-T] START END",
+			Description: "List the transactions in a range of blocks.",
+			Flags: []flagSpec{
+				{Name: "-T"},
+			},
+			Args: []argSpec{
+				{Kind: "START"},
+				{Kind: "END"},
+			},
+		},
+		{
+			Path:        "listtxs tmp",
+			Usage:       "factom-cli listtxs tmp",
+			Description: "List the transactions in the wallet that have not been sent.",
+		},
+		{
+			Path:        "newecaddress",
+			Usage:       "factom-cli newecaddress",
+			Description: "Generate a new Entry Credit address in the wallet.",
+		},
+		{
+			Path:        "newfctaddress",
+			Usage:       "factom-cli newfctaddress",
+			Description: "Generate a new Factoid address in the wallet.",
+		},
+		{
+			Path:        "newtx",
+			Usage:       "factom-cli newtx [-q] TXNAME",
+			Description: "Create a new transaction in the wallet.",
+			Flags: []flagSpec{
+				{Name: "-q"},
+			},
+			Args: []argSpec{
+				{Kind: "TXNAME"},
+			},
+		},
+		{
+			Path:        "properties",
+			Usage:       "factom-cli properties",
+			Description: "Get the version of the wallet, factomd and the API.",
+		},
+		{
+			Path:        "receipt",
+			Usage:       "factom-cli receipt ENTRYHASH",
+			Description: "Get a receipt proving an Entry is in the blockchain.",
+			Args: []argSpec{
+				{Kind: "ENTRYHASH"},
+			},
+		},
+		{
+			Path:        "rmaddress",
+			Usage:       "factom-cli rmaddress ADDRESS",
+			Description: "Remove an address from the wallet.",
+			Args: []argSpec{
+				{Kind: "ADDRESS"},
+			},
+		},
+		{
+			Path:        "rmtx",
+			Usage:       "factom-cli rmtx TXNAME",
+			Description: "Remove a transaction from the wallet.",
+			Args: []argSpec{
+				{Kind: "TXNAME"},
+			},
+		},
+		{
+			Path:        "sendfct",
+			Usage:       "factom-cli sendfct [-fqrT] FROMADDRESS TOADDRESS AMOUNT",
+			Description: "Send Factoids from one address to another.",
+			Flags: []flagSpec{
+				{Name: "-f"},
+				{Name: "-q"},
+				{Name: "-r"},
+				{Name: "-T"},
+			},
+			Args: []argSpec{
+				{Kind: "FROMADDRESS"},
+				{Kind: "TOADDRESS"},
+				{Kind: "AMOUNT"},
+			},
+		},
+		{
+			Path:        "sendtx",
+			Usage:       "factom-cli sendtx [-fqT] TXNAME",
+			Description: "Send a signed transaction from the wallet to factomd.",
+			Flags: []flagSpec{
+				{Name: "-f"},
+				{Name: "-q"},
+				{Name: "-T"},
+			},
+			Args: []argSpec{
+				{Kind: "TXNAME"},
+			},
+		},
+		{
+			Path:        "signtx",
+			Usage:       "factom-cli signtx [-fqT] TXNAME",
+			Description: "Sign a transaction in the wallet.",
+			Flags: []flagSpec{
+				{Name: "-f"},
+				{Name: "-q"},
+				{Name: "-T"},
+			},
+			Args: []argSpec{
+				{Kind: "TXNAME"},
+			},
+		},
+		{
+			Path:        "status",
+			Usage:       "factom-cli status TxID|FullTx",
+			Description: "Get the status of a transaction or an Entry.",
+			Args: []argSpec{
+				{Kind: "TxID|FullTx"},
+			},
+		},
+		{
+			Path:        "subtxfee",
+			Usage:       "factom-cli subtxfee [-q] TXNAME ADDRESS",
+			Description: "Subtract the transaction fee from an output of a transaction in the wallet.",
+			Flags: []flagSpec{
+				{Name: "-q"},
+			},
+			Args: []argSpec{
+				{Kind: "TXNAME"},
+				{Kind: "ADDRESS"},
+			},
+		},
+	},
+	"v2.2.14": {
+		{
+			Path:  "",
+			Usage: "factom-cli [OPTIONS] SUBCOMMAND [OPTIONS]",
+			Flags: []flagSpec{
+				{Name: "-factomdcert", Arg: "string", Description: "path to the factomd TLS certificate (default \"~/.factom/m2/factomdAPIpub.cert\")"},
+				{Name: "-factomdpassword", Arg: "string", Description: "password for API connections to factomd"},
+				{Name: "-factomdtls", Description: "set to true to use TLS when connecting to factomd"},
+				{Name: "-factomduser", Arg: "string", Description: "username for API connections to factomd"},
+				{Name: "-s", Arg: "string", Description: "IPAddr:port# of factomd API to use to access blockchain (default \"localhost:8088\")"},
+				{Name: "-w", Arg: "string", Description: "IPAddr:port# of factom-walletd API to use to create transactions (default \"localhost:8089\")"},
+				{Name: "-walletcert", Arg: "string", Description: "path to the factom-walletd TLS certificate (default \"~/.factom/walletAPIpub.cert\")"},
+				{Name: "-walletpassword", Arg: "string", Description: "password for API connections to factom-walletd"},
+				{Name: "-wallettls", Description: "set to true to use TLS when connecting to factom-walletd"},
+				{Name: "-walletuser", Arg: "string", Description: "username for API connections to factom-walletd"},
+			},
+		},
+		{
+			Path:        "addchain",
+			Usage:       "factom-cli addchain [-fq] [-n NAME1 -n NAME2 -h HEXNAME3 ] [-CET] ECADDRESS <STDIN>",
+			Description: "Create a new Factom Chain. Read data for the First Entry from stdin. Use the Entry Credits from the specified address.",
+			Flags: []flagSpec{
+				{Name: "-f"},
+				{Name: "-q"},
+				{Name: "-n", Arg: "NAME", Repeatable: true},
+				{Name: "-h", Arg: "HEXNAME", Repeatable: true},
+				{Name: "-C"},
+				{Name: "-E"},
+				{Name: "-T"},
+			},
+			Args: []argSpec{
+				{Kind: "ECADDRESS"},
+			},
+		},
+		{
+			Path:        "addentry",
+			Usage:       "factom-cli addentry [-fq] [-n NAME1 -h HEXNAME2 ...|-c CHAINID] [-e EXTID1 -e EXTID2 -x HEXEXTID ...] [-CET] ECADDRESS <STDIN>",
+			Description: "Create a new Factom Entry. Read data for the Entry from stdin. Use the Entry Credits from the specified address.",
+			Flags: []flagSpec{
+				{Name: "-f"},
+				{Name: "-q"},
+				{Name: "-n", Arg: "NAME", Repeatable: true},
+				{Name: "-h", Arg: "HEXNAME", Repeatable: true},
+				{Name: "-c", Arg: "CHAINID"},
+				{Name: "-e", Arg: "EXTID", Repeatable: true},
+				{Name: "-x", Arg: "HEXEXTID", Repeatable: true},
+				{Name: "-C"},
+				{Name: "-E"},
+				{Name: "-T"},
+			},
+			Args: []argSpec{
+				{Kind: "ECADDRESS"},
+			},
+			Exclusive: [][]string{
+				{"-n -h", "-c"},
+			},
+		},
+		{
+			Path:        "addtxecoutput",
+			Usage:       "factom-cli addtxecoutput [-rq] TXNAME ADDRESS AMOUNT",
+			Description: "Add an Entry Credit output to a transaction in the wallet.",
+			Flags: []flagSpec{
+				{Name: "-r"},
+				{Name: "-q"},
+			},
+			Args: []argSpec{
+				{Kind: "TXNAME"},
+				{Kind: "ADDRESS"},
+				{Kind: "AMOUNT"},
+			},
+		},
+		{
+			Path:        "addtxfee",
+			Usage:       "factom-cli addtxfee [-q] TXNAME ADDRESS",
+			Description: "Add the transaction fee to an input of a transaction in the wallet.",
+			Flags: []flagSpec{
+				{Name: "-q"},
+			},
+			Args: []argSpec{
+				{Kind: "TXNAME"},
+				{Kind: "ADDRESS"},
+			},
+		},
+		{
+			Path:        "addtxinput",
+			Usage:       "factom-cli addtxinput [-q] TXNAME ADDRESS AMOUNT",
+			Description: "Add a Factoid input to a transaction in the wallet.",
+			Flags: []flagSpec{
+				{Name: "-q"},
+			},
+			Args: []argSpec{
+				{Kind: "TXNAME"},
+				{Kind: "ADDRESS"},
+				{Kind: "AMOUNT"},
+			},
+		},
+		{
+			Path:        "addtxoutput",
+			Usage:       "factom-cli addtxoutput [-rq] TXNAME ADDRESS AMOUNT",
+			Description: "Add a Factoid output to a transaction in the wallet.",
+			Flags: []flagSpec{
+				{Name: "-r"},
+				{Name: "-q"},
+			},
+			Args: []argSpec{
+				{Kind: "TXNAME"},
+				{Kind: "ADDRESS"},
+				{Kind: "AMOUNT"},
+			},
+		},
+		{
+			Path:        "backupwallet",
+			Usage:       "factom-cli backupwallet",
+			Description: "Backup the wallet seed and all imported addresses.",
+		},
+		{
+			Path:        "balance",
+			Usage:       "factom-cli balance [-r] ADDRESS",
+			Description: "If this is an EC Address, returns the number of Entry Credits. If this is a Factoid Address, returns the Factoid balance.",
+			Flags: []flagSpec{
+				{Name: "-r"},
+			},
+			Args: []argSpec{
+				{Kind: "ADDRESS"},
+			},
+		},
+		{
+			Path:        "buyec",
+			Usage:       "factom-cli buyec [-fqrT] FCTADDRESS ECADDRESS ECAMOUNT",
+			Description: "Buy the specified number of Entry Credits with Factoids.",
+			Flags: []flagSpec{
+				{Name: "-f"},
+				{Name: "-q"},
+				{Name: "-r"},
+				{Name: "-T"},
+			},
+			Args: []argSpec{
+				{Kind: "FCTADDRESS"},
+				{Kind: "ECADDRESS"},
+				{Kind: "ECAMOUNT"},
+			},
+		},
+		{
+			Path:        "composechain",
+			Usage:       "factom-cli composechain [-f] [-n NAME1 -n NAME2 -h HEXNAME3 ] ECADDRESS <STDIN>",
+			Description: "Create API calls to create a new Factom Chain. Read data for the First Entry from stdin.",
+			Flags: []flagSpec{
+				{Name: "-f"},
+				{Name: "-n", Arg: "NAME", Repeatable: true},
+				{Name: "-h", Arg: "HEXNAME", Repeatable: true},
+			},
+			Args: []argSpec{
+				{Kind: "ECADDRESS"},
+			},
+		},
+		{
+			Path:        "composeentry",
+			Usage:       "factom-cli composeentry [-f] [-n NAME1 -h HEXNAME2 ...|-c CHAINID]  [-e EXTID1 -e EXTID2 -x HEXEXTID ...] ECADDRESS <STDIN>",
+			Description: "Create API calls to create a new Factom Entry. Read data for the Entry from stdin.",
+			Flags: []flagSpec{
+				{Name: "-f"},
+				{Name: "-n", Arg: "NAME", Repeatable: true},
+				{Name: "-h", Arg: "HEXNAME", Repeatable: true},
+				{Name: "-c", Arg: "CHAINID"},
+				{Name: "-e", Arg: "EXTID", Repeatable: true},
+				{Name: "-x", Arg: "HEXEXTID", Repeatable: true},
+			},
+			Args: []argSpec{
+				{Kind: "ECADDRESS"},
+			},
+			Exclusive: [][]string{
+				{"-n -h", "-c"},
+			},
+		},
+		{
+			Path:        "composetx",
+			Usage:       "factom-cli composetx TXNAME",
+			Description: "Compose a wallet transaction into a JSON RPC object.",
+			Args: []argSpec{
+				{Kind: "TXNAME"},
+			},
+		},
+		{
+			Path:        "ecrate",
+			Usage:       "factom-cli ecrate",
+			Description: "Show the number of Factoshis needed to buy 1 Entry Credit.",
+		},
+		{
+			Path:        "exportaddresses",
+			Usage:       "factom-cli exportaddresses",
+			Description: "Export the secret keys of all addresses in the wallet.",
+		},
+		{
+			Path:        "get",
+			Usage:       "factom-cli get allentries|chainhead|dblock|eblock|entry|firstentry|head|heights|walletheight|pendingentries|pendingtransactions|raw|dbheight|abheight|fbheight|ecbheight",
+			Description: "Get data from the Factom blockchain.",
+		},
+		{
+			Path:        "get abheight",
+			Usage:       "factom-cli get abheight HEIGHT -r (to suppress Raw Data)",
+			Description: "Get the Admin Block at the specified height.",
+			Flags: []flagSpec{
+				{Name: "-r"},
+			},
+			Args: []argSpec{
+				{Kind: "HEIGHT"},
+			},
+		},
+		{
+			Path:        "get allentries",
+			Usage:       "factom-cli get allentries [-n NAME1 -h HEXNAME2 ...|CHAINID] [-E]",
+			Description: "Get all of the Entries in a Chain.",
+			Flags: []flagSpec{
+				{Name: "-n", Arg: "NAME", Repeatable: true},
+				{Name: "-h", Arg: "HEXNAME", Repeatable: true},
+				{Name: "-E"},
+			},
+			Args: []argSpec{
+				{Kind: "CHAINID", Optional: true},
+			},
+			Exclusive: [][]string{
+				{"-n -h", "CHAINID"},
+			},
+		},
+		{
+			Path:        "get chainhead",
+			Usage:       "factom-cli get chainhead [-n NAME1 -h HEXNAME2 ...|CHAINID] [-K]",
+			Description: "Get the latest Entry Block of a Chain.",
+			Flags: []flagSpec{
+				{Name: "-n", Arg: "NAME", Repeatable: true},
+				{Name: "-h", Arg: "HEXNAME", Repeatable: true},
+				{Name: "-K"},
+			},
+			Args: []argSpec{
+				{Kind: "CHAINID", Optional: true},
+			},
+			Exclusive: [][]string{
+				{"-n -h", "CHAINID"},
+			},
+		},
+		{
+			Path:        "get dbheight",
+			Usage:       "factom-cli get dbheight HEIGHT -r (to suppress Raw Data)",
+			Description: "Get the Directory Block at the specified height.",
+			Flags: []flagSpec{
+				{Name: "-r"},
+			},
+			Args: []argSpec{
+				{Kind: "HEIGHT"},
+			},
+		},
+		{
+			Path:        "get dblock",
+			Usage:       "factom-cli get dblock KEYMR",
+			Description: "Get a Directory Block by its KeyMR.",
+			Args: []argSpec{
+				{Kind: "KEYMR"},
+			},
+		},
+		{
+			Path:        "get eblock",
+			Usage:       "factom-cli get eblock KEYMR",
+			Description: "Get an Entry Block by its KeyMR.",
+			Args: []argSpec{
+				{Kind: "KEYMR"},
+			},
+		},
+		{
+			Path:        "get ecbheight",
+			Usage:       "factom-cli get ecbheight HEIGHT -r (to suppress Raw Data)",
+			Description: "Get the Entry Credit Block at the specified height.",
+			Flags: []flagSpec{
+				{Name: "-r"},
+			},
+			Args: []argSpec{
+				{Kind: "HEIGHT"},
+			},
+		},
+		{
+			Path:        "get entry",
+			Usage:       "factom-cli get entry HASH",
+			Description: "Get an Entry by its hash.",
+			Args: []argSpec{
+				{Kind: "HASH"},
+			},
+		},
+		{
+			Path:        "get fbheight",
+			Usage:       "factom-cli get fbheight HEIGHT -r (to suppress Raw Data)",
+			Description: "Get the Factoid Block at the specified height.",
+			Flags: []flagSpec{
+				{Name: "-r"},
+			},
+			Args: []argSpec{
+				{Kind: "HEIGHT"},
+			},
+		},
+		{
+			Path:        "get firstentry",
+			Usage:       "factom-cli get firstentry [-n NAME1 -h HEXNAME2 ...|CHAINID] [-E]",
+			Description: "Get the first Entry of a Chain.",
+			Flags: []flagSpec{
+				{Name: "-n", Arg: "NAME", Repeatable: true},
+				{Name: "-h", Arg: "HEXNAME", Repeatable: true},
+				{Name: "-E"},
+			},
+			Args: []argSpec{
+				{Kind: "CHAINID", Optional: true},
+			},
+			Exclusive: [][]string{
+				{"-n -h", "CHAINID"},
+			},
+		},
+		{
+			Path:        "get head",
+			Usage:       "factom-cli get head [-K]",
+			Description: "Get the latest Directory Block.",
+			Flags: []flagSpec{
+				{Name: "-K"},
+			},
+		},
+		{
+			Path:        "get heights",
+			Usage:       "factom-cli get heights",
+			Description: "Get the current heights of the blockchain.",
+		},
+		{
+			Path:        "get pendingentries",
+			Usage:       "factom-cli get pendingentries [-E]",
+			Description: "Get the Entries that are not yet in a block.",
+			Flags: []flagSpec{
+				{Name: "-E"},
+			},
+		},
+		{
+			Path:        "get pendingtransactions",
+			Usage:       "factom-cli get pendingtransactions [-T]",
+			Description: "Get the transactions that are not yet in a block.",
+			Flags: []flagSpec{
+				{Name: "-T"},
+			},
+		},
+		{
+			Path:        "get raw",
+			Usage:       "factom-cli get raw HASH",
+			Description: "Get the raw data of a block or Entry by its hash.",
+			Args: []argSpec{
+				{Kind: "HASH"},
+			},
+		},
+		{
+			Path:        "get walletheight",
+			Usage:       "factom-cli get walletheight",
+			Description: "Get the height up to which the wallet has synced.",
+		},
+		{
+			Path:        "help",
+			Usage:       "factom-cli help [SUBCOMMAND]",
+			Description: "Print the help of a subcommand.",
+			Args: []argSpec{
+				{Kind: "SUBCOMMAND", Optional: true},
+			},
+		},
+		{
+			Path:        "importaddress",
+			Usage:       "factom-cli importaddress ADDRESS [ADDRESS...]",
+			Description: "Import one or more secret keys into the wallet.",
+			Args: []argSpec{
+				{Kind: "ADDRESS", Variadic: true},
+			},
+		},
+		{
+			Path:        "importkoinify",
+			Usage:       "factom-cli importkoinify '12WORDS'",
+			Description: "Import a Koinify crowd sale address from its 12 word mnemonic.",
+			Args: []argSpec{
+				{Kind: "12WORDS"},
+			},
+		},
+		{
+			Path:        "listaddresses",
+			Usage:       "factom-cli listaddresses",
+			Description: "List the addresses in the wallet and their balances.",
+		},
+		{
+			Path:        "listtxs",
+			Usage:       "factom-cli listtxs [address|all|id|name|tmp|range]",
+			Description: "List transactions from the wallet or the blockchain.",
+			Flags: []flagSpec{
+				{Name: "-T"},
+			},
+		},
+		{
+			Path:        "listtxs address",
+			Usage:       "factom-cli listtxs address [-T] ECADDRESS|FCTADDRESS",
+			Description: "List the transactions of an address.",
+			Flags: []flagSpec{
+				{Name: "-T"},
+			},
+			Args: []argSpec{
+				{Kind: "ECADDRESS|FCTADDRESS"},
+			},
+		},
+		{
+			Path:        "listtxs all",
+			Usage:       "factom-cli listtxs [all] [-T]",
+			Description: "List all transactions.",
+			Flags: []flagSpec{
+				{Name: "-T"},
+			},
+		},
+		{
+			Path:        "listtxs id",
+			Usage:       "factom-cli listtxs id TXID",
+			Description: "List a transaction by its TXID.",
+			Args: []argSpec{
+				{Kind: "TXID"},
+			},
+		},
+		{
+			Path:        "listtxs name",
+			Usage:       "factom-cli listtxs name TXNAME",
+			Description: "List a transaction in the wallet by its name.",
+			Args: []argSpec{
+				{Kind: "TXNAME"},
+			},
+		},
+		{
+			Path:        "listtxs range",
+			Usage:       "factom-cli listtxs range [-T] START END",
+			Description: "List the transactions in a range of blocks.",
+			Flags: []flagSpec{
+				{Name: "-T"},
+			},
+			Args: []argSpec{
+				{Kind: "START"},
+				{Kind: "END"},
+			},
+		},
+		{
+			Path:        "listtxs tmp",
+			Usage:       "factom-cli listtxs tmp [-N]",
+			Description: "List the transactions in the wallet that have not been sent.",
+			Flags: []flagSpec{
+				{Name: "-N"},
+			},
+		},
+		{
+			Path:        "newecaddress",
+			Usage:       "factom-cli newecaddress",
+			Description: "Generate a new Entry Credit address in the wallet.",
+		},
+		{
+			Path:        "newfctaddress",
+			Usage:       "factom-cli newfctaddress",
+			Description: "Generate a new Factoid address in the wallet.",
+		},
+		{
+			Path:        "newtx",
+			Usage:       "factom-cli newtx [-q] TXNAME",
+			Description: "Create a new transaction in the wallet.",
+			Flags: []flagSpec{
+				{Name: "-q"},
+			},
+			Args: []argSpec{
+				{Kind: "TXNAME"},
+			},
+		},
+		{
+			Path:        "properties",
+			Usage:       "factom-cli properties",
+			Description: "Get the version of the wallet, factomd and the API.",
+		},
+		{
+			Path:        "receipt",
+			Usage:       "factom-cli receipt ENTRYHASH",
+			Description: "Get a receipt proving an Entry is in the blockchain.",
+			Args: []argSpec{
+				{Kind: "ENTRYHASH"},
+			},
+		},
+		{
+			Path:        "rmaddress",
+			Usage:       "factom-cli rmaddress ADDRESS",
+			Description: "Remove an address from the wallet.",
+			Args: []argSpec{
+				{Kind: "ADDRESS"},
+			},
+		},
+		{
+			Path:        "rmtx",
+			Usage:       "factom-cli rmtx TXNAME",
+			Description: "Remove a transaction from the wallet.",
+			Args: []argSpec{
+				{Kind: "TXNAME"},
+			},
+		},
+		{
+			Path:        "sendfct",
+			Usage:       "factom-cli sendfct [-fqrT] FROMADDRESS TOADDRESS AMOUNT",
+			Description: "Send Factoids from one address to another.",
+			Flags: []flagSpec{
+				{Name: "-f"},
+				{Name: "-q"},
+				{Name: "-r"},
+				{Name: "-T"},
+			},
+			Args: []argSpec{
+				{Kind: "FROMADDRESS"},
+				{Kind: "TOADDRESS"},
+				{Kind: "AMOUNT"},
+			},
+		},
+		{
+			Path:        "sendtx",
+			Usage:       "factom-cli sendtx [-fqT] TXNAME",
+			Description: "Send a signed transaction from the wallet to factomd.",
+			Flags: []flagSpec{
+				{Name: "-f"},
+				{Name: "-q"},
+				{Name: "-T"},
+			},
+			Args: []argSpec{
+				{Kind: "TXNAME"},
+			},
+		},
+		{
+			Path:        "signtx",
+			Usage:       "factom-cli signtx [-fqT] TXNAME",
+			Description: "Sign a transaction in the wallet.",
+			Flags: []flagSpec{
+				{Name: "-f"},
+				{Name: "-q"},
+				{Name: "-T"},
+			},
+			Args: []argSpec{
+				{Kind: "TXNAME"},
+			},
+		},
+		{
+			Path:        "status",
+			Usage:       "factom-cli status TxID|FullTx",
+			Description: "Get the status of a transaction or an Entry.",
+			Args: []argSpec{
+				{Kind: "TxID|FullTx"},
+			},
+		},
+		{
+			Path:        "subtxfee",
+			Usage:       "factom-cli subtxfee [-q] TXNAME ADDRESS",
+			Description: "Subtract the transaction fee from an output of a transaction in the wallet.",
+			Flags: []flagSpec{
+				{Name: "-q"},
+			},
+			Args: []argSpec{
+				{Kind: "TXNAME"},
+				{Kind: "ADDRESS"},
+			},
+		},
+	},
+	"v2.2.15": {
+		{
+			Path:  "",
+			Usage: "factom-cli [OPTIONS] SUBCOMMAND [OPTIONS]",
+			Flags: []flagSpec{
+				{Name: "-factomdcert", Arg: "string", Description: "path to the factomd TLS certificate (default \"~/.factom/m2/factomdAPIpub.cert\")"},
+				{Name: "-factomdpassword", Arg: "string", Description: "password for API connections to factomd"},
+				{Name: "-factomdtls", Description: "set to true to use TLS when connecting to factomd"},
+				{Name: "-factomduser", Arg: "string", Description: "username for API connections to factomd"},
+				{Name: "-s", Arg: "string", Description: "IPAddr:port# of factomd API to use to access blockchain (default \"localhost:8088\")"},
+				{Name: "-w", Arg: "string", Description: "IPAddr:port# of factom-walletd API to use to create transactions (default \"localhost:8089\")"},
+				{Name: "-walletcert", Arg: "string", Description: "path to the factom-walletd TLS certificate (default \"~/.factom/walletAPIpub.cert\")"},
+				{Name: "-walletpassword", Arg: "string", Description: "password for API connections to factom-walletd"},
+				{Name: "-wallettls", Description: "set to true to use TLS when connecting to factom-walletd"},
+				{Name: "-walletuser", Arg: "string", Description: "username for API connections to factom-walletd"},
+			},
+		},
+		{
+			Path:        "addchain",
+			Usage:       "factom-cli addchain [-fq] [-n NAME1 -n NAME2 -h HEXNAME3 ] [-CET] ECADDRESS <STDIN>",
+			Description: "Create a new Factom Chain. Read data for the First Entry from stdin. Use the Entry Credits from the specified address.",
+			Flags: []flagSpec{
+				{Name: "-f"},
+				{Name: "-q"},
+				{Name: "-n", Arg: "NAME", Repeatable: true},
+				{Name: "-h", Arg: "HEXNAME", Repeatable: true},
+				{Name: "-C"},
+				{Name: "-E"},
+				{Name: "-T"},
+			},
+			Args: []argSpec{
+				{Kind: "ECADDRESS"},
+			},
+		},
+		{
+			Path:        "addentry",
+			Usage:       "factom-cli addentry [-fq] [-n NAME1 -h HEXNAME2 ...|-c CHAINID] [-e EXTID1 -e EXTID2 -x HEXEXTID ...] [-CET] ECADDRESS <STDIN>",
+			Description: "Create a new Factom Entry. Read data for the Entry from stdin. Use the Entry Credits from the specified address.",
+			Flags: []flagSpec{
+				{Name: "-f"},
+				{Name: "-q"},
+				{Name: "-n", Arg: "NAME", Repeatable: true},
+				{Name: "-h", Arg: "HEXNAME", Repeatable: true},
+				{Name: "-c", Arg: "CHAINID"},
+				{Name: "-e", Arg: "EXTID", Repeatable: true},
+				{Name: "-x", Arg: "HEXEXTID", Repeatable: true},
+				{Name: "-C"},
+				{Name: "-E"},
+				{Name: "-T"},
+			},
+			Args: []argSpec{
+				{Kind: "ECADDRESS"},
+			},
+			Exclusive: [][]string{
+				{"-n -h", "-c"},
+			},
+		},
+		{
+			Path:        "addtxecoutput",
+			Usage:       "factom-cli addtxecoutput [-rq] TXNAME ADDRESS AMOUNT",
+			Description: "Add an Entry Credit output to a transaction in the wallet.",
+			Flags: []flagSpec{
+				{Name: "-r"},
+				{Name: "-q"},
+			},
+			Args: []argSpec{
+				{Kind: "TXNAME"},
+				{Kind: "ADDRESS"},
+				{Kind: "AMOUNT"},
+			},
+		},
+		{
+			Path:        "addtxfee",
+			Usage:       "factom-cli addtxfee [-q] TXNAME ADDRESS",
+			Description: "Add the transaction fee to an input of a transaction in the wallet.",
+			Flags: []flagSpec{
+				{Name: "-q"},
+			},
+			Args: []argSpec{
+				{Kind: "TXNAME"},
+				{Kind: "ADDRESS"},
+			},
+		},
+		{
+			Path:        "addtxinput",
+			Usage:       "factom-cli addtxinput [-q] TXNAME ADDRESS AMOUNT",
+			Description: "Add a Factoid input to a transaction in the wallet.",
+			Flags: []flagSpec{
+				{Name: "-q"},
+			},
+			Args: []argSpec{
+				{Kind: "TXNAME"},
+				{Kind: "ADDRESS"},
+				{Kind: "AMOUNT"},
+			},
+		},
+		{
+			Path:        "addtxoutput",
+			Usage:       "factom-cli addtxoutput [-rq] TXNAME ADDRESS AMOUNT",
+			Description: "Add a Factoid output to a transaction in the wallet.",
+			Flags: []flagSpec{
+				{Name: "-r"},
+				{Name: "-q"},
+			},
+			Args: []argSpec{
+				{Kind: "TXNAME"},
+				{Kind: "ADDRESS"},
+				{Kind: "AMOUNT"},
+			},
+		},
+		{
+			Path:        "backupwallet",
+			Usage:       "factom-cli backupwallet",
+			Description: "Backup the wallet seed and all imported addresses.",
+		},
+		{
+			Path:        "balance",
+			Usage:       "factom-cli balance [-r] ADDRESS",
+			Description: "If this is an EC Address, returns the number of Entry Credits. If this is a Factoid Address, returns the Factoid balance.",
+			Flags: []flagSpec{
+				{Name: "-r"},
+			},
+			Args: []argSpec{
+				{Kind: "ADDRESS"},
+			},
+		},
+		{
+			Path:        "buyec",
+			Usage:       "factom-cli buyec [-fqrT] FCTADDRESS ECADDRESS ECAMOUNT",
+			Description: "Buy the specified number of Entry Credits with Factoids.",
+			Flags: []flagSpec{
+				{Name: "-f"},
+				{Name: "-q"},
+				{Name: "-r"},
+				{Name: "-T"},
+			},
+			Args: []argSpec{
+				{Kind: "FCTADDRESS"},
+				{Kind: "ECADDRESS"},
+				{Kind: "ECAMOUNT"},
+			},
+		},
+		{
+			Path:        "composechain",
+			Usage:       "factom-cli composechain [-f] [-n NAME1 -n NAME2 -h HEXNAME3 ] ECADDRESS <STDIN>",
+			Description: "Create API calls to create a new Factom Chain. Read data for the First Entry from stdin.",
+			Flags: []flagSpec{
+				{Name: "-f"},
+				{Name: "-n", Arg: "NAME", Repeatable: true},
+				{Name: "-h", Arg: "HEXNAME", Repeatable: true},
+			},
+			Args: []argSpec{
+				{Kind: "ECADDRESS"},
+			},
+		},
+		{
+			Path:        "composeentry",
+			Usage:       "factom-cli composeentry [-f] [-n NAME1 -h HEXNAME2 ...|-c CHAINID]  [-e EXTID1 -e EXTID2 -x HEXEXTID ...] ECADDRESS <STDIN>",
+			Description: "Create API calls to create a new Factom Entry. Read data for the Entry from stdin.",
+			Flags: []flagSpec{
+				{Name: "-f"},
+				{Name: "-n", Arg: "NAME", Repeatable: true},
+				{Name: "-h", Arg: "HEXNAME", Repeatable: true},
+				{Name: "-c", Arg: "CHAINID"},
+				{Name: "-e", Arg: "EXTID", Repeatable: true},
+				{Name: "-x", Arg: "HEXEXTID", Repeatable: true},
+			},
+			Args: []argSpec{
+				{Kind: "ECADDRESS"},
+			},
+			Exclusive: [][]string{
+				{"-n -h", "-c"},
+			},
+		},
+		{
+			Path:        "composetx",
+			Usage:       "factom-cli composetx TXNAME",
+			Description: "Compose a wallet transaction into a JSON RPC object.",
+			Args: []argSpec{
+				{Kind: "TXNAME"},
+			},
+		},
+		{
+			Path:        "ecrate",
+			Usage:       "factom-cli ecrate",
+			Description: "Show the number of Factoshis needed to buy 1 Entry Credit.",
+		},
+		{
+			Path:        "exportaddresses",
+			Usage:       "factom-cli exportaddresses",
+			Description: "Export the secret keys of all addresses in the wallet.",
+		},
+		{
+			Path:        "get",
+			Usage:       "factom-cli get allentries|chainhead|dblock|eblock|entry|firstentry|head|heights|walletheight|pendingentries|pendingtransactions|raw|dbheight|abheight|fbheight|ecbheight",
+			Description: "Get data from the Factom blockchain.",
+		},
+		{
+			Path:        "get abheight",
+			Usage:       "factom-cli get abheight HEIGHT -r (to suppress Raw Data)",
+			Description: "Get the Admin Block at the specified height.",
+			Flags: []flagSpec{
+				{Name: "-r"},
+			},
+			Args: []argSpec{
+				{Kind: "HEIGHT"},
+			},
+		},
+		{
+			Path:        "get allentries",
+			Usage:       "factom-cli get allentries [-n NAME1 -h HEXNAME2 ...|CHAINID] [-E]",
+			Description: "Get all of the Entries in a Chain.",
+			Flags: []flagSpec{
+				{Name: "-n", Arg: "NAME", Repeatable: true},
+				{Name: "-h", Arg: "HEXNAME", Repeatable: true},
+				{Name: "-E"},
+			},
+			Args: []argSpec{
+				{Kind: "CHAINID", Optional: true},
+			},
+			Exclusive: [][]string{
+				{"-n -h", "CHAINID"},
+			},
+		},
+		{
+			Path:        "get chainhead",
+			Usage:       "factom-cli get chainhead [-n NAME1 -h HEXNAME2 ...|CHAINID] [-K]",
+			Description: "Get the latest Entry Block of a Chain.",
+			Flags: []flagSpec{
+				{Name: "-n", Arg: "NAME", Repeatable: true},
+				{Name: "-h", Arg: "HEXNAME", Repeatable: true},
+				{Name: "-K"},
+			},
+			Args: []argSpec{
+				{Kind: "CHAINID", Optional: true},
+			},
+			Exclusive: [][]string{
+				{"-n -h", "CHAINID"},
+			},
+		},
+		{
+			Path:        "get dbheight",
+			Usage:       "factom-cli get dbheight HEIGHT -r (to suppress Raw Data)",
+			Description: "Get the Directory Block at the specified height.",
+			Flags: []flagSpec{
+				{Name: "-r"},
+			},
+			Args: []argSpec{
+				{Kind: "HEIGHT"},
+			},
+		},
+		{
+			Path:        "get dblock",
+			Usage:       "factom-cli get dblock KEYMR",
+			Description: "Get a Directory Block by its KeyMR.",
+			Args: []argSpec{
+				{Kind: "KEYMR"},
+			},
+		},
+		{
+			Path:        "get eblock",
+			Usage:       "factom-cli get eblock KEYMR",
+			Description: "Get an Entry Block by its KeyMR.",
+			Args: []argSpec{
+				{Kind: "KEYMR"},
+			},
+		},
+		{
+			Path:        "get ecbheight",
+			Usage:       "factom-cli get ecbheight HEIGHT -r (to suppress Raw Data)",
+			Description: "Get the Entry Credit Block at the specified height.",
+			Flags: []flagSpec{
+				{Name: "-r"},
+			},
+			Args: []argSpec{
+				{Kind: "HEIGHT"},
+			},
+		},
+		{
+			Path:        "get entry",
+			Usage:       "factom-cli get entry HASH",
+			Description: "Get an Entry by its hash.",
+			Args: []argSpec{
+				{Kind: "HASH"},
+			},
+		},
+		{
+			Path:        "get fbheight",
+			Usage:       "factom-cli get fbheight HEIGHT -r (to suppress Raw Data)",
+			Description: "Get the Factoid Block at the specified height.",
+			Flags: []flagSpec{
+				{Name: "-r"},
+			},
+			Args: []argSpec{
+				{Kind: "HEIGHT"},
+			},
+		},
+		{
+			Path:        "get firstentry",
+			Usage:       "factom-cli get firstentry [-n NAME1 -h HEXNAME2 ...|CHAINID] [-E]",
+			Description: "Get the first Entry of a Chain.",
+			Flags: []flagSpec{
+				{Name: "-n", Arg: "NAME", Repeatable: true},
+				{Name: "-h", Arg: "HEXNAME", Repeatable: true},
+				{Name: "-E"},
+			},
+			Args: []argSpec{
+				{Kind: "CHAINID", Optional: true},
+			},
+			Exclusive: [][]string{
+				{"-n -h", "CHAINID"},
+			},
+		},
+		{
+			Path:        "get head",
+			Usage:       "factom-cli get head [-K]",
+			Description: "Get the latest Directory Block.",
+			Flags: []flagSpec{
+				{Name: "-K"},
+			},
+		},
+		{
+			Path:        "get heights",
+			Usage:       "factom-cli get heights [-DLBE]",
+			Description: "Get the current heights of the blockchain. -D print only the Directory Block height, -L only the leader height, -B only the Entry Block height, -E only the Entry height.",
+			Flags: []flagSpec{
+				{Name: "-D"},
+				{Name: "-L"},
+				{Name: "-B"},
+				{Name: "-E"},
+			},
+		},
+		{
+			Path:        "get pendingentries",
+			Usage:       "factom-cli get pendingentries [-E]",
+			Description: "Get the Entries that are not yet in a block.",
+			Flags: []flagSpec{
+				{Name: "-E"},
+			},
+		},
+		{
+			Path:        "get pendingtransactions",
+			Usage:       "factom-cli get pendingtransactions [-T]",
+			Description: "Get the transactions that are not yet in a block.",
+			Flags: []flagSpec{
+				{Name: "-T"},
+			},
+		},
+		{
+			Path:        "get raw",
+			Usage:       "factom-cli get raw HASH",
+			Description: "Get the raw data of a block or Entry by its hash.",
+			Args: []argSpec{
+				{Kind: "HASH"},
+			},
+		},
+		{
+			Path:        "get walletheight",
+			Usage:       "factom-cli get walletheight",
+			Description: "Get the height up to which the wallet has synced.",
+		},
+		{
+			Path:        "help",
+			Usage:       "factom-cli help [SUBCOMMAND]",
+			Description: "Print the help of a subcommand.",
+			Args: []argSpec{
+				{Kind: "SUBCOMMAND", Optional: true},
+			},
+		},
+		{
+			Path:        "importaddress",
+			Usage:       "factom-cli importaddress ADDRESS [ADDRESS...]",
+			Description: "Import one or more secret keys into the wallet.",
+			Args: []argSpec{
+				{Kind: "ADDRESS", Variadic: true},
+			},
+		},
+		{
+			Path:        "importkoinify",
+			Usage:       "factom-cli importkoinify '12WORDS'",
+			Description: "Import a Koinify crowd sale address from its 12 word mnemonic.",
+			Args: []argSpec{
+				{Kind: "12WORDS"},
+			},
+		},
+		{
+			Path:        "listaddresses",
+			Usage:       "factom-cli listaddresses",
+			Description: "List the addresses in the wallet and their balances.",
+		},
+		{
+			Path:        "listtxs",
+			Usage:       "factom-cli listtxs [address|all|id|name|tmp|range]",
+			Description: "List transactions from the wallet or the blockchain.",
+			Flags: []flagSpec{
+				{Name: "-T"},
+			},
+		},
+		{
+			Path:        "listtxs address",
+			Usage:       "factom-cli listtxs address [-T] ECADDRESS|FCTADDRESS",
+			Description: "List the transactions of an address.",
+			Flags: []flagSpec{
+				{Name: "-T"},
+			},
+			Args: []argSpec{
+				{Kind: "ECADDRESS|FCTADDRESS"},
+			},
+		},
+		{
+			Path:        "listtxs all",
+			Usage:       "factom-cli listtxs [all] [-T]",
+			Description: "List all transactions.",
+			Flags: []flagSpec{
+				{Name: "-T"},
+			},
+		},
+		{
+			Path:        "listtxs id",
+			Usage:       "factom-cli listtxs id TXID",
+			Description: "List a transaction by its TXID.",
+			Args: []argSpec{
+				{Kind: "TXID"},
+			},
+		},
+		{
+			Path:        "listtxs name",
+			Usage:       "factom-cli listtxs name TXNAME",
+			Description: "List a transaction in the wallet by its name.",
+			Args: []argSpec{
+				{Kind: "TXNAME"},
+			},
+		},
+		{
+			Path:        "listtxs range",
+			Usage:       "factom-cli listtxs range [-T] START END",
+			Description: "List the transactions in a range of blocks.",
+			Flags: []flagSpec{
+				{Name: "-T"},
+			},
+			Args: []argSpec{
+				{Kind: "START"},
+				{Kind: "END"},
+			},
+		},
+		{
+			Path:        "listtxs tmp",
+			Usage:       "factom-cli listtxs tmp [-N]",
+			Description: "List the transactions in the wallet that have not been sent.",
+			Flags: []flagSpec{
+				{Name: "-N"},
+			},
+		},
+		{
+			Path:        "newecaddress",
+			Usage:       "factom-cli newecaddress",
+			Description: "Generate a new Entry Credit address in the wallet.",
+		},
+		{
+			Path:        "newfctaddress",
+			Usage:       "factom-cli newfctaddress",
+			Description: "Generate a new Factoid address in the wallet.",
+		},
+		{
+			Path:        "newtx",
+			Usage:       "factom-cli newtx [-q] TXNAME",
+			Description: "Create a new transaction in the wallet.",
+			Flags: []flagSpec{
+				{Name: "-q"},
+			},
+			Args: []argSpec{
+				{Kind: "TXNAME"},
+			},
+		},
+		{
+			Path:        "properties",
+			Usage:       "factom-cli properties",
+			Description: "Get the version of the wallet, factomd and the API.",
+		},
+		{
+			Path:        "receipt",
+			Usage:       "factom-cli receipt ENTRYHASH",
+			Description: "Get a receipt proving an Entry is in the blockchain.",
+			Args: []argSpec{
+				{Kind: "ENTRYHASH"},
+			},
+		},
+		{
+			Path:        "rmaddress",
+			Usage:       "factom-cli rmaddress ADDRESS",
+			Description: "Remove an address from the wallet.",
+			Args: []argSpec{
+				{Kind: "ADDRESS"},
+			},
+		},
+		{
+			Path:        "rmtx",
+			Usage:       "factom-cli rmtx TXNAME",
+			Description: "Remove a transaction from the wallet.",
+			Args: []argSpec{
+				{Kind: "TXNAME"},
+			},
+		},
+		{
+			Path:        "sendfct",
+			Usage:       "factom-cli sendfct [-fqrT] FROMADDRESS TOADDRESS AMOUNT",
+			Description: "Send Factoids from one address to another.",
+			Flags: []flagSpec{
+				{Name: "-f"},
+				{Name: "-q"},
+				{Name: "-r"},
+				{Name: "-T"},
+			},
+			Args: []argSpec{
+				{Kind: "FROMADDRESS"},
+				{Kind: "TOADDRESS"},
+				{Kind: "AMOUNT"},
+			},
+		},
+		{
+			Path:        "sendtx",
+			Usage:       "factom-cli sendtx [-fqT] TXNAME",
+			Description: "Send a signed transaction from the wallet to factomd.",
+			Flags: []flagSpec{
+				{Name: "-f"},
+				{Name: "-q"},
+				{Name: "-T"},
+			},
+			Args: []argSpec{
+				{Kind: "TXNAME"},
+			},
+		},
+		{
+			Path:        "signtx",
+			Usage:       "factom-cli signtx [-fqT] TXNAME",
+			Description: "Sign a transaction in the wallet.",
+			Flags: []flagSpec{
+				{Name: "-f"},
+				{Name: "-q"},
+				{Name: "-T"},
+			},
+			Args: []argSpec{
+				{Kind: "TXNAME"},
+			},
+		},
+		{
+			Path:        "status",
+			Usage:       "factom-cli status TxID|FullTx",
+			Description: "Get the status of a transaction or an Entry.",
+			Args: []argSpec{
+				{Kind: "TxID|FullTx"},
+			},
+		},
+		{
+			Path:        "subtxfee",
+			Usage:       "factom-cli subtxfee [-q] TXNAME ADDRESS",
+			Description: "Subtract the transaction fee from an output of a transaction in the wallet.",
+			Flags: []flagSpec{
+				{Name: "-q"},
+			},
+			Args: []argSpec{
+				{Kind: "TXNAME"},
+				{Kind: "ADDRESS"},
+			},
+		},
+	},
+}
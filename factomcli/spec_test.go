@@ -0,0 +1,78 @@
+package factomcli
+
+import (
+	"bytes"
+	"io/ioutil"
+	"testing"
+
+	"github.com/AdamSLevy/complete-factom-cli/factomcli/internal/usage"
+)
+
+// TestSpecGenerated checks that the spec files are generated from the
+// current usage text fixtures of each program.
+func TestSpecGenerated(t *testing.T) {
+	tests := []struct {
+		program, name, file string
+	}{
+		{"factom-cli", "factomCLISpecs", "spec_gen.go"},
+		{"factomd", "factomdSpecs", "spec_factomd_gen.go"},
+		{"factom-walletd", "walletdSpecs", "spec_walletd_gen.go"},
+	}
+	for _, test := range tests {
+		source := "testdata/" + test.program
+		helps, err := usage.ParseDir(source, test.program)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var want bytes.Buffer
+		err = usage.Generate(&want, source, "factomcli", test.name, helps)
+		if err != nil {
+			t.Fatal(err)
+		}
+		got, err := ioutil.ReadFile(test.file)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(got, want.Bytes()) {
+			t.Errorf("%v differs from %v, run go generate",
+				test.file, source)
+		}
+	}
+}
+
+// TestSpecPredictors checks that every flag value and positional argument
+// in the usage texts of each program has a predictor.
+func TestSpecPredictors(t *testing.T) {
+	tests := []struct {
+		specs      map[string][]commandSpec
+		predictors predictorTable
+	}{
+		{factomCLISpecs, factomCLIPredictors(rpcPredictors)},
+		{factomdSpecs, factomdPredictors},
+		{walletdSpecs, walletdPredictors},
+	}
+	for _, test := range tests {
+		for version, specs := range test.specs {
+			checkPredictors(t, version, specs, test.predictors)
+		}
+	}
+}
+
+func checkPredictors(t *testing.T, version string, specs []commandSpec,
+	predictors predictorTable) {
+	tree := newCommandTree(specs, predictors)
+	for _, spec := range specs {
+		for _, f := range spec.Flags {
+			if _, ok := tree.flagPredictor(spec.Path, f); !ok {
+				t.Errorf("%v %q: no predictor for %v %v",
+					version, spec.Path, f.Name, f.Arg)
+			}
+		}
+		for _, a := range spec.Args {
+			if _, ok := tree.argPredictor(spec.Path, a.Kind); !ok {
+				t.Errorf("%v %q: no predictor for %v",
+					version, spec.Path, a.Kind)
+			}
+		}
+	}
+}
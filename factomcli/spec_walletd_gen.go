@@ -0,0 +1,30 @@
+// Code generated by specgen from testdata/factom-walletd. DO NOT EDIT.
+
+package factomcli
+
+var walletdSpecs = map[string][]commandSpec{
+	"v2.2.14": {
+		{
+			Path: "",
+			Flags: []flagSpec{
+				{Name: "-b", Description: "use a BoltDB wallet database (the default)"},
+				{Name: "-cert", Arg: "string", Description: "path to the TLS certificate of the wallet API (default \"~/.factom/walletAPIpub.cert\")"},
+				{Name: "-e", Description: "use an encrypted wallet database"},
+				{Name: "-factomdcert", Arg: "string", Description: "path to the factomd TLS certificate (default \"~/.factom/m2/factomdAPIpub.cert\")"},
+				{Name: "-factomdpassword", Arg: "string", Description: "password for API connections to factomd"},
+				{Name: "-factomdtls", Description: "set to true to use TLS when connecting to factomd"},
+				{Name: "-factomduser", Arg: "string", Description: "username for API connections to factomd"},
+				{Name: "-i", Arg: "string", Description: "import a version 1 wallet from the given file"},
+				{Name: "-key", Arg: "string", Description: "path to the TLS private key of the wallet API (default \"~/.factom/walletAPIpriv.key\")"},
+				{Name: "-l", Description: "use a LevelDB wallet database"},
+				{Name: "-m", Description: "use an in-memory wallet database"},
+				{Name: "-p", Arg: "int", Description: "set the port to host the wallet API (default 8089)"},
+				{Name: "-password", Arg: "string", Description: "password for API connections to the wallet"},
+				{Name: "-s", Arg: "string", Description: "IPAddr:port# of factomd API to use to access blockchain (default \"localhost:8088\")"},
+				{Name: "-tls", Description: "set to true to require encrypted connections to the wallet API"},
+				{Name: "-user", Arg: "string", Description: "username for API connections to the wallet"},
+				{Name: "-w", Arg: "string", Description: "path to the wallet database (default \"~/.factom/wallet/factom_wallet.db\")"},
+			},
+		},
+	},
+}
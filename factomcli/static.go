@@ -0,0 +1,312 @@
+package factomcli
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/posener/complete"
+)
+
+// staticValues are the candidates of a flag value or positional argument
+// that a static completion script can produce without this program.
+type staticValues struct {
+	words []string
+	// files is the pattern of the files to complete, if any.
+	files string
+	dirs  bool
+	lists []staticList
+}
+
+func (v staticValues) empty() bool {
+	return len(v.words) == 0 && len(v.files) == 0 && !v.dirs &&
+		len(v.lists) == 0
+}
+
+// staticList is a list of words that a static completion script gets from
+// the first column of the output of a factom-cli command. The command is run
+// with the connection flags on the line being completed.
+type staticList struct {
+	group   string
+	command string
+	// prefix selects the words of the list.
+	prefix string
+}
+
+var (
+	staticFCTAddresses = staticList{groupFCTAddresses, "listaddresses", "FA"}
+	staticECAddresses  = staticList{groupECAddresses, "listaddresses", "EC"}
+	staticTmpTxs       = staticList{groupTmpTxs, "listtxs tmp", ""}
+)
+
+// staticPredictor is a predictor whose candidates a static completion
+// script can produce.
+type staticPredictor struct {
+	complete.Predictor
+	values staticValues
+}
+
+// PredictArgs passes the preceding positional arguments on to the wrapped
+// predictor if it depends on them.
+func (p staticPredictor) PredictArgs(a complete.Args, prev []string) []string {
+	if predictor, ok := p.Predictor.(ArgsPredictor); ok {
+		return predictor.PredictArgs(a, prev)
+	}
+	return p.Predictor.Predict(a)
+}
+
+// predictSet is complete.PredictSet for static completion scripts.
+func predictSet(words ...string) complete.Predictor {
+	return staticPredictor{complete.PredictSet(words...),
+		staticValues{words: words}}
+}
+
+// predictFiles is complete.PredictFiles for static completion scripts.
+func predictFiles(pattern string) complete.Predictor {
+	return staticPredictor{complete.PredictFiles(pattern),
+		staticValues{files: pattern}}
+}
+
+// predictDirs is complete.PredictDirs for static completion scripts.
+func predictDirs(pattern string) complete.Predictor {
+	return staticPredictor{complete.PredictDirs(pattern),
+		staticValues{dirs: true}}
+}
+
+// listed marks p as predicting words of the lists, which static completion
+// scripts complete instead.
+func listed(p complete.Predictor, lists ...staticList) complete.Predictor {
+	return staticPredictor{p, staticValues{lists: lists}}
+}
+
+// listedAddresses marks p as predicting FCT and EC addresses.
+func listedAddresses(p complete.Predictor) complete.Predictor {
+	return listed(p, staticFCTAddresses, staticECAddresses)
+}
+
+// listedFCT marks p as predicting FCT addresses.
+func listedFCT(p complete.Predictor) complete.Predictor {
+	return listed(p, staticFCTAddresses)
+}
+
+// listedEC marks p as predicting EC addresses.
+func listedEC(p complete.Predictor) complete.Predictor {
+	return listed(p, staticECAddresses)
+}
+
+// listedTxNames marks p as predicting the names of tmp transactions.
+func listedTxNames(p complete.Predictor) complete.Predictor {
+	return listed(p, staticTmpTxs)
+}
+
+// staticValuesOf returns the staticValues of p, which are empty unless p is
+// a staticPredictor.
+func staticValuesOf(p complete.Predictor) staticValues {
+	if sp, ok := p.(staticPredictor); ok {
+		return sp.values
+	}
+	return staticValues{}
+}
+
+// staticCommand is a command of a program as a static completion script
+// completes it.
+type staticCommand struct {
+	path  string
+	subs  []staticWord
+	flags []staticWord
+	// values holds the values of the flags that take one.
+	values   map[string]staticValues
+	args     []staticValues
+	variadic bool
+}
+
+// staticWord is a sub command or flag and its description.
+type staticWord struct {
+	word, desc string
+}
+
+// valueFlags returns the sorted names of the flags of c that take a value.
+func (c staticCommand) valueFlags() []string {
+	var names []string
+	for name := range c.values {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// staticCommands returns the static commands of cmd at path and of all of
+// its sub commands, described by help.
+func staticCommands(cmd complete.Command, help commandHelp,
+	path []string) []staticCommand {
+	c := staticCommand{
+		path:   strings.Join(path, " "),
+		values: make(map[string]staticValues),
+	}
+	for name := range cmd.Sub {
+		spec := help[strings.Join(append(path, name), " ")]
+		c.subs = append(c.subs, staticWord{name, commandDescription(spec)})
+	}
+	sort.Slice(c.subs, func(i, j int) bool {
+		return c.subs[i].word < c.subs[j].word
+	})
+	for name, p := range cmd.Flags {
+		c.flags = append(c.flags,
+			staticWord{name, help.flagDescription(path, name)})
+		if p != nil {
+			c.values[name] = staticValuesOf(p)
+		}
+	}
+	sort.Slice(c.flags, func(i, j int) bool {
+		return c.flags[i].word < c.flags[j].word
+	})
+	switch args := cmd.Args.(type) {
+	case positional:
+		for _, p := range args.args {
+			c.args = append(c.args, staticValuesOf(p))
+		}
+		c.variadic = args.variadic
+	case nil:
+	default:
+		c.args, c.variadic = []staticValues{staticValuesOf(args)}, true
+	}
+
+	commands := []staticCommand{c}
+	for _, sub := range c.subs {
+		commands = append(commands, staticCommands(cmd.Sub[sub.word], help,
+			append(path, sub.word))...)
+	}
+	return commands
+}
+
+// staticShell writes the parts of a static completion script that differ
+// between shells. The script consists of a header with the completion
+// function and its helpers, a data function that
+// prints the sub commands and flags of a command, a values function that
+// completes the value of a flag or the n-th positional argument "#n" of a
+// command, and a footer. Both functions switch on "program|kind|path" and
+// "program|path|flag" respectively.
+type staticShell struct {
+	header, footer string
+	// function starts the function of the given name and its switch,
+	// which end ends.
+	function, end string
+	// caseStart starts a case of pattern, which matches any suffix if
+	// wildcard is true. The commands of a case are indented by indent and
+	// followed by caseEnd.
+	caseStart func(pattern string, wildcard bool) string
+	indent    string
+	caseEnd   string
+	// dataKinds are the kinds of data that the script uses. data returns
+	// the commands that print the words of a kind.
+	dataKinds []string
+	data      func(kind string, words []staticWord) []string
+	// values returns the commands that complete v.
+	values func(v staticValues) []string
+}
+
+// staticShells are the shells that static completion scripts are written
+// for.
+var staticShells = map[string]staticShell{
+	"bash": bashStatic,
+	"zsh":  zshStatic,
+	"fish": fishStatic,
+}
+
+// writeStaticScript writes a completion script for shell that completes the
+// commands, sub commands and flags of the programs without this program.
+// Only the FCT and EC addresses and the tmp transactions are listed by
+// running factom-cli.
+func writeStaticScript(w io.Writer, shell string, programs []program) error {
+	s, ok := staticShells[shell]
+	if !ok {
+		return fmt.Errorf("no static completion for %q, "+
+			"use bash, zsh or fish", shell)
+	}
+	names := make([]string, len(programs))
+	commands := make([][]staticCommand, len(programs))
+	for i, p := range programs {
+		names[i] = p.name
+		commands[i] = staticCommands(p.command, p.help, nil)
+	}
+
+	fmt.Fprintf(w, "# Static completion of %s.\n", strings.Join(names, ", "))
+	fmt.Fprintf(w, "# Generated by complete-factom-cli -script %s.\n\n", shell)
+	fmt.Fprint(w, s.header)
+	fmt.Fprintf(w, s.function, "data")
+	for i, name := range names {
+		for _, c := range commands[i] {
+			for _, kind := range s.dataKinds {
+				if words := c.dataWords(kind); len(words) > 0 {
+					s.writeCase(w, name+"|"+kind+"|"+c.path, false,
+						s.data(kind, words))
+				}
+			}
+		}
+	}
+	fmt.Fprint(w, s.end)
+	fmt.Fprintf(w, s.function, "values")
+	for i, name := range names {
+		for _, c := range commands[i] {
+			prefix := name + "|" + c.path + "|"
+			for _, flag := range c.valueFlags() {
+				s.writeValues(w, prefix+flag, false, c.values[flag])
+			}
+			for n, v := range c.args {
+				if c.variadic && n == len(c.args)-1 {
+					s.writeValues(w, prefix+"#", true, v)
+					continue
+				}
+				s.writeValues(w, fmt.Sprintf("%s#%d", prefix, n+1), false, v)
+			}
+		}
+	}
+	fmt.Fprint(w, s.end)
+	_, err := fmt.Fprintf(w, s.footer, strings.Join(names, " "))
+	return err
+}
+
+// writeValues writes the case of the values function that completes v.
+func (s staticShell) writeValues(w io.Writer, pattern string, wildcard bool,
+	v staticValues) {
+	if !v.empty() {
+		s.writeCase(w, pattern, wildcard, s.values(v))
+	}
+}
+
+// writeCase writes a case of pattern that runs the commands.
+func (s staticShell) writeCase(w io.Writer, pattern string, wildcard bool,
+	commands []string) {
+	fmt.Fprint(w, s.caseStart(pattern, wildcard))
+	for _, command := range commands {
+		fmt.Fprintln(w, s.indent+command)
+	}
+	fmt.Fprint(w, s.caseEnd)
+}
+
+// dataWords returns the words of the data kind of c.
+func (c staticCommand) dataWords(kind string) []staticWord {
+	switch kind {
+	case "subs", "subsdesc":
+		return c.subs
+	case "flags", "flagsdesc":
+		return c.flags
+	case "valueflags":
+		var words []staticWord
+		for _, name := range c.valueFlags() {
+			words = append(words, staticWord{word: name})
+		}
+		return words
+	}
+	return nil
+}
+
+// wordList returns the words without their descriptions.
+func wordList(words []staticWord) []string {
+	list := make([]string, len(words))
+	for i, w := range words {
+		list[i] = w.word
+	}
+	return list
+}
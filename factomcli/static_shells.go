@@ -0,0 +1,380 @@
+package factomcli
+
+import (
+	"strings"
+)
+
+var bashStatic = staticShell{
+	header: `_factom_cli_static() {
+	local -a words
+	local cword
+	_factom_cli_static_split
+	local cur=${words[cword]} program=${words[0]##*/}
+	local cmdpath= word i nargs=0
+	program=${program%.exe}
+	COMPREPLY=()
+	for ((i = 1; i < cword; i++)); do
+		word=${words[i]}
+		if [[ $word == -* ]]; then
+			if [[ " $(_factom_cli_static_data "$program" valueflags "$cmdpath") " == *" $word "* ]]; then
+				if ((i + 1 == cword)); then
+					_factom_cli_static_values "$program" "$cmdpath" "$word"
+					return
+				fi
+				((i++))
+			fi
+		elif ((nargs == 0)) && [[ " $(_factom_cli_static_data "$program" subs "$cmdpath") " == *" $word "* ]]; then
+			cmdpath=${cmdpath:+$cmdpath }$word
+		else
+			((nargs++))
+		fi
+	done
+	if [[ $cur == -* ]]; then
+		_factom_cli_static_words "$(_factom_cli_static_data "$program" flags "$cmdpath")"
+		return
+	fi
+	if ((nargs == 0)); then
+		_factom_cli_static_words "$(_factom_cli_static_data "$program" subs "$cmdpath")"
+	fi
+	_factom_cli_static_values "$program" "$cmdpath" "#$((nargs + 1))"
+}
+
+# Sets words and cword to COMP_WORDS and COMP_CWORD with the words that bash
+# split at the characters of COMP_WORDBREAKS, such as : and =, joined again.
+_factom_cli_static_split() {
+	local line=$COMP_LINE rest i
+	words=() cword=0
+	for ((i = 0; i < ${#COMP_WORDS[@]}; i++)); do
+		rest=${line#"${line%%[![:space:]]*}"}
+		if ((i == 0)) || [[ $rest != "$line" ]]; then
+			words+=("")
+		fi
+		words[${#words[@]} - 1]+=${COMP_WORDS[i]}
+		line=${rest:${#COMP_WORDS[i]}}
+		if ((i == COMP_CWORD)); then
+			cword=$((${#words[@]} - 1))
+		fi
+	done
+}
+
+_factom_cli_static_words() {
+	COMPREPLY+=($(compgen -W "$1" -- "$cur"))
+}
+
+_factom_cli_static_list() {
+	local -a conn=()
+	local word i
+	for ((i = 1; i < cword; i++)); do
+		word=${words[i]}
+		[[ $word == -* ]] || break
+		conn+=("$word")
+		if [[ " $(_factom_cli_static_data factom-cli valueflags '') " == *" $word "* ]]; then
+			conn+=("${words[i + 1]}")
+			((i++))
+		fi
+	done
+	_factom_cli_static_words "$(factom-cli "${conn[@]}" $1 2>/dev/null |
+		awk -v p="$2" 'index($1, p) == 1 { print $1 }')"
+}
+
+_factom_cli_static_files() {
+	compopt -o filenames 2>/dev/null
+	COMPREPLY+=($(compgen -f -X "!$1" -- "$cur"))
+	if [[ $1 != '*' ]]; then
+		COMPREPLY+=($(compgen -d -- "$cur"))
+	fi
+}
+
+_factom_cli_static_dirs() {
+	compopt -o filenames 2>/dev/null
+	COMPREPLY+=($(compgen -d -- "$cur"))
+}
+`,
+	footer: "\ncomplete -F _factom_cli_static %s\n",
+	function: "\n_factom_cli_static_%s() {\n" +
+		"\tcase \"$1|$2|$3\" in\n",
+	end: "\tesac\n}\n",
+	caseStart: func(pattern string, wildcard bool) string {
+		return "\t" + shellCasePattern(pattern, wildcard) + ")\n"
+	},
+	indent:    "\t\t",
+	caseEnd:   "\t\t;;\n",
+	dataKinds: []string{"subs", "flags", "valueflags"},
+	data: func(_ string, words []staticWord) []string {
+		return []string{"echo " + shellQuote(strings.Join(wordList(words),
+			" "))}
+	},
+	values: func(v staticValues) []string {
+		var commands []string
+		if len(v.words) > 0 {
+			commands = append(commands, "_factom_cli_static_words "+
+				shellQuote(strings.Join(v.words, " ")))
+		}
+		if len(v.files) > 0 {
+			commands = append(commands,
+				"_factom_cli_static_files "+shellQuote(v.files))
+		}
+		if v.dirs {
+			commands = append(commands, "_factom_cli_static_dirs")
+		}
+		for _, l := range v.lists {
+			commands = append(commands, "_factom_cli_static_list "+
+				shellQuote(l.command)+" "+shellQuote(l.prefix))
+		}
+		return commands
+	},
+}
+
+var zshStatic = staticShell{
+	header: `_factom_cli_static() {
+	local program=${${words[1]:t}%.exe} cmdpath= word
+	local -i i nargs=0
+	for ((i = 2; i < CURRENT; i++)); do
+		word=${words[i]}
+		if [[ $word == -* ]]; then
+			if [[ " $(_factom_cli_static_data $program valueflags "$cmdpath") " == *" $word "* ]]; then
+				if ((i + 1 == CURRENT)); then
+					_factom_cli_static_values $program "$cmdpath" $word
+					return
+				fi
+				((i++))
+			fi
+		elif ((nargs == 0)) && [[ " $(_factom_cli_static_data $program subs "$cmdpath") " == *" $word "* ]]; then
+			cmdpath=${cmdpath:+$cmdpath }$word
+		else
+			((nargs++))
+		fi
+	done
+	if [[ $PREFIX == -* ]]; then
+		_factom_cli_static_described flags $program flagsdesc "$cmdpath"
+		return
+	fi
+	if ((nargs == 0)); then
+		_factom_cli_static_described commands $program subsdesc "$cmdpath"
+	fi
+	_factom_cli_static_values $program "$cmdpath" "#$((nargs + 1))"
+}
+
+_factom_cli_static_described() {
+	local group=$1
+	local -a described
+	shift
+	described=("${(@f)$(_factom_cli_static_data "$@")}")
+	(( ${#described[@]} )) && [[ -n $described[1] ]] &&
+		_describe -t $group $group described
+}
+
+_factom_cli_static_words() {
+	compadd -- "$@"
+}
+
+_factom_cli_static_list() {
+	local -a listed conn
+	local -i i
+	for ((i = 2; i < CURRENT; i++)); do
+		[[ $words[i] == -* ]] || break
+		conn+=(${(Q)words[i]})
+		if [[ " $(_factom_cli_static_data factom-cli valueflags '') " == *" $words[i] "* ]]; then
+			conn+=(${(Q)words[i + 1]})
+			((i++))
+		fi
+	done
+	listed=(${(f)"$(factom-cli $conn ${=2} 2>/dev/null |
+		awk -v p="$3" 'index($1, p) == 1 { print $1 }')"})
+	_describe -t ${1// /-} $1 listed
+}
+
+(( $+functions[compdef] )) || { autoload -Uz compinit && compinit; }
+`,
+	footer: "\ncompdef _factom_cli_static %s\n",
+	function: "\n_factom_cli_static_%s() {\n" +
+		"\tcase \"$1|$2|$3\" in\n",
+	end: "\tesac\n}\n",
+	caseStart: func(pattern string, wildcard bool) string {
+		return "\t" + shellCasePattern(pattern, wildcard) + ")\n"
+	},
+	indent:    "\t\t",
+	caseEnd:   "\t\t;;\n",
+	dataKinds: []string{"subs", "valueflags", "subsdesc", "flagsdesc"},
+	data: func(kind string, words []staticWord) []string {
+		if !strings.HasSuffix(kind, "desc") {
+			return []string{"print -r -- " +
+				shellQuote(strings.Join(wordList(words), " "))}
+		}
+		lines := []string{"print -rl -- \\"}
+		for i, w := range words {
+			line := "\t" + shellQuote(describeZsh(w.word, w.desc))
+			if i < len(words)-1 {
+				line += " \\"
+			}
+			lines = append(lines, line)
+		}
+		return lines
+	},
+	values: func(v staticValues) []string {
+		var commands []string
+		if len(v.words) > 0 {
+			commands = append(commands, "_factom_cli_static_words "+
+				shellQuoteAll(v.words))
+		}
+		if len(v.files) > 0 {
+			commands = append(commands, "_files -g "+shellQuote(v.files))
+		}
+		if v.dirs {
+			commands = append(commands, "_files -/")
+		}
+		for _, l := range v.lists {
+			commands = append(commands, "_factom_cli_static_list "+
+				shellQuoteAll([]string{l.group, l.command, l.prefix}))
+		}
+		return commands
+	},
+}
+
+var fishStatic = staticShell{
+	header: `function __factom_cli_static
+    set -l tokens (commandline -opc)
+    set -l cur (commandline -ct)
+    set -l program (string replace -r '\.exe$' '' -- (basename -- $tokens[1]))
+    set -e tokens[1]
+    set -l cmdpath ''
+    set -l nargs 0
+    set -l flag ''
+    for word in $tokens
+        if test -n "$flag"
+            set flag ''
+        else if string match -q -- '-*' $word
+            if contains -- $word (__factom_cli_static_data $program valueflags "$cmdpath")
+                set flag $word
+            end
+        else if test $nargs -eq 0; and contains -- $word (__factom_cli_static_data $program subs "$cmdpath")
+            set cmdpath (string trim -- "$cmdpath $word")
+        else
+            set nargs (math $nargs + 1)
+        end
+    end
+    if test -n "$flag"
+        __factom_cli_static_values $program "$cmdpath" $flag
+        return
+    end
+    if string match -q -- '-*' "$cur"
+        __factom_cli_static_data $program flagsdesc "$cmdpath"
+        return
+    end
+    if test $nargs -eq 0
+        __factom_cli_static_data $program subsdesc "$cmdpath"
+    end
+    __factom_cli_static_values $program "$cmdpath" "#"(math $nargs + 1)
+end
+
+function __factom_cli_static_list -a group command prefix
+    set -l tokens (commandline -opc)
+    set -e tokens[1]
+    set -l conn
+    while set -q tokens[1]; and string match -q -- '-*' $tokens[1]
+        set conn $conn $tokens[1]
+        if contains -- $tokens[1] (__factom_cli_static_data factom-cli valueflags '')
+            set conn $conn $tokens[2]
+            set -e tokens[1]
+        end
+        set -e tokens[1]
+    end
+    for word in (factom-cli $conn (string split ' ' -- $command) 2>/dev/null | awk -v p="$prefix" 'index($1, p) == 1 { print $1 }')
+        printf '%s\t%s\n' $word $group
+    end
+end
+
+function __factom_cli_static_files -a pattern dirs
+    set -l cur (commandline -ct)
+    for file in $cur*
+        if test -d $file
+            echo $file/
+        else if test -z "$dirs"; and string match -q -- $pattern (basename -- $file)
+            echo $file
+        end
+    end
+end
+`,
+	footer: "\n" + `for program in %s
+    complete -c $program -f -a '(__factom_cli_static)'
+end
+`,
+	function: "\nfunction __factom_cli_static_%s\n" +
+		"    switch \"$argv[1]|$argv[2]|$argv[3]\"\n",
+	end: "    end\nend\n",
+	caseStart: func(pattern string, wildcard bool) string {
+		if wildcard {
+			pattern += "*"
+		}
+		return "        case " + fishQuote(pattern) + "\n"
+	},
+	indent:    "            ",
+	dataKinds: []string{"subs", "valueflags", "subsdesc", "flagsdesc"},
+	data: func(kind string, words []staticWord) []string {
+		if !strings.HasSuffix(kind, "desc") {
+			return []string{`printf '%s\n' ` +
+				fishQuoteAll(wordList(words))}
+		}
+		lines := []string{`printf '%s\t%s\n' \`}
+		for i, w := range words {
+			line := "    " + fishQuoteAll([]string{w.word, w.desc})
+			if i < len(words)-1 {
+				line += " \\"
+			}
+			lines = append(lines, line)
+		}
+		return lines
+	},
+	values: func(v staticValues) []string {
+		var commands []string
+		if len(v.words) > 0 {
+			commands = append(commands, `printf '%s\n' `+
+				fishQuoteAll(v.words))
+		}
+		if len(v.files) > 0 {
+			commands = append(commands,
+				"__factom_cli_static_files "+fishQuote(v.files))
+		}
+		if v.dirs {
+			commands = append(commands, "__factom_cli_static_files '' dirs")
+		}
+		for _, l := range v.lists {
+			commands = append(commands, "__factom_cli_static_list "+
+				fishQuoteAll([]string{l.group, l.command, l.prefix}))
+		}
+		return commands
+	},
+}
+
+// shellCasePattern returns the pattern of a case in bash or zsh.
+func shellCasePattern(pattern string, wildcard bool) string {
+	pattern = "'" + strings.Replace(pattern, "'", `'\''`, -1) + "'"
+	if wildcard {
+		pattern += "*"
+	}
+	return pattern
+}
+
+// describeZsh returns a word and its description as _describe expects.
+func describeZsh(word, desc string) string {
+	word = strings.Replace(word, ":", `\:`, -1)
+	if len(desc) == 0 {
+		return word
+	}
+	return word + ":" + desc
+}
+
+// fishQuote quotes s as a single word for fish.
+func fishQuote(s string) string {
+	return "'" + strings.NewReplacer(`\`, `\\`, "'", `\'`).Replace(s) + "'"
+}
+
+// fishQuoteAll quotes each of words with fishQuote and joins them with
+// spaces.
+func fishQuoteAll(words []string) string {
+	quoted := make([]string, len(words))
+	for i, w := range words {
+		quoted[i] = fishQuote(w)
+	}
+	return strings.Join(quoted, " ")
+}
@@ -0,0 +1,178 @@
+package factomcli
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/posener/complete"
+)
+
+// TestStaticBash runs the static bash completion script with a fake
+// factom-cli that lists addresses and tmp transactions, which depend on the
+// connection flags.
+func TestStaticBash(t *testing.T) {
+	bash, err := exec.LookPath("bash")
+	if err != nil {
+		t.Skip("bash is not installed")
+	}
+	dir, err := ioutil.TempDir("", "complete-factom-cli")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	writeTestFile(t, filepath.Join(dir, "factom-cli"), "#!/bin/sh\n"+
+		"case \"$*\" in\n"+
+		"listaddresses) echo \"FA2fct 5\"; echo \"EC2ec 10\" ;;\n"+
+		"'-w remote:8089 listaddresses') echo \"FA3remote 1\" ;;\n"+
+		"'listtxs tmp') echo \"tx1 0123 0 0 0\" ;;\n"+
+		"'-wallettls -walletuser=me listtxs tmp') echo \"tx2 0 0 0 0\" ;;\n"+
+		"esac\n")
+	if err := os.Chmod(filepath.Join(dir, "factom-cli"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	writeTestFile(t, filepath.Join(dir, "program.conf"), "")
+	script := filepath.Join(dir, "complete.bash")
+	var buf bytes.Buffer
+	if err := writeStaticScript(&buf, "bash", newCLI("", rpcClient{}).programs()); err != nil {
+		t.Fatal(err)
+	}
+	writeTestFile(t, script, buf.String())
+
+	tests := []struct {
+		line string
+		want string
+	}{
+		{"factom-cli addtxi", "addtxinput"},
+		{"factom-cli addtxinput ", "tx1"},
+		{"factom-cli addtxinput tx1 ", "FA2fct"},
+		{"factom-cli balance ", "FA2fct EC2ec"},
+		{"factom-cli -w remote:8089 balance ", "FA3remote"},
+		{"factom-cli -wallettls -walletuser=me rmtx ", "tx2"},
+		{"factom-cli buyec FA2fct ", "EC2ec"},
+		{"factom-cli get h", "head heights"},
+		{"factom-cli listtxs tmp -", "-N"},
+		{"factom-cli help get", "get"},
+		{"factom-cli -walletu", "-walletuser"},
+		{"factomd -network ", "MAIN TEST LOCAL CUSTOM"},
+		{"factomd -config pro", "program.conf"},
+		{"factom-walletd -tl", "-tls"},
+	}
+	for _, test := range tests {
+		words := bashCompWords(test.line)
+		cmd := exec.Command(bash, "-c", "source "+script+"\n"+
+			"COMP_LINE="+shellQuote(test.line)+"\n"+
+			"COMP_POINT=${#COMP_LINE}\n"+
+			"COMP_WORDS=("+shellQuoteAll(words)+")\n"+
+			"COMP_CWORD="+strconv.Itoa(len(words)-1)+"\n"+
+			"_factom_cli_static\n"+
+			"echo \"${COMPREPLY[*]}\"")
+		cmd.Dir = dir
+		cmd.Env = append(os.Environ(),
+			"PATH="+dir+string(os.PathListSeparator)+os.Getenv("PATH"))
+		out, err := cmd.CombinedOutput()
+		if err != nil {
+			t.Fatalf("%q: %v: %s", test.line, err, out)
+		}
+		if got := strings.TrimSpace(string(out)); got != test.want {
+			t.Errorf("%q: got %q, want %q", test.line, got, test.want)
+		}
+	}
+}
+
+// bashCompWords splits a line that has no quotes into COMP_WORDS the way bash
+// does with the default COMP_WORDBREAKS: at white space, and around each :
+// and =, which are words of their own. The last word is the one being
+// completed.
+func bashCompWords(line string) []string {
+	var words []string
+	for _, field := range strings.Split(line, " ") {
+		if len(field) == 0 {
+			continue
+		}
+		word := ""
+		for _, r := range field {
+			if r == ':' || r == '=' {
+				if len(word) > 0 {
+					words = append(words, word)
+				}
+				words = append(words, string(r))
+				word = ""
+				continue
+			}
+			word += string(r)
+		}
+		if len(word) > 0 {
+			words = append(words, word)
+		}
+	}
+	if strings.HasSuffix(line, " ") {
+		words = append(words, "")
+	}
+	return words
+}
+
+// TestStaticScripts checks the cases that the zsh and fish scripts complete.
+func TestStaticScripts(t *testing.T) {
+	programs := newCLI("", rpcClient{}).programs()
+	tests := []struct {
+		shell string
+		want  []string
+	}{
+		{"zsh", []string{
+			"\t'factom-cli|addtxinput|#1')\n" +
+				"\t\t_factom_cli_static_list 'tmp transactions' " +
+				"'listtxs tmp' ''\n",
+			"\t'factom-cli|flagsdesc|')\n\t\tprint -rl -- \\\n" +
+				"\t\t\t'-factomdcert:path to the factomd TLS certificate",
+			"\t'factomd||-config')\n\t\t_files -g '*.conf'\n",
+			"\ncompdef _factom_cli_static factom-cli factomd factom-walletd\n",
+			"factom-cli $conn ${=2} 2>/dev/null",
+		}},
+		{"fish", []string{
+			"        case 'factom-cli|balance|#1'\n" +
+				"            __factom_cli_static_list 'FCT addresses' " +
+				"'listaddresses' 'FA'\n" +
+				"            __factom_cli_static_list 'EC addresses' " +
+				"'listaddresses' 'EC'\n",
+			"        case 'factom-cli|help|#1'\n",
+			"        case 'factomd||-network'\n" +
+				"            printf '%s\\n' 'MAIN' 'TEST' 'LOCAL' 'CUSTOM'\n",
+			"\nfor program in factom-cli factomd factom-walletd\n",
+			"factom-cli $conn (string split ' ' -- $command) 2>/dev/null",
+		}},
+	}
+	for _, test := range tests {
+		var buf bytes.Buffer
+		if err := writeStaticScript(&buf, test.shell, programs); err != nil {
+			t.Fatal(err)
+		}
+		for _, want := range test.want {
+			if !strings.Contains(buf.String(), want) {
+				t.Errorf("%s script does not contain %q", test.shell, want)
+			}
+		}
+	}
+
+	// A predictor of all positional arguments matches any number.
+	var buf bytes.Buffer
+	err := writeStaticScript(&buf, "bash", []program{
+		{name: "prog", command: complete.Command{Args: predictSet("a")}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := "\t'prog||#'*)\n\t\t_factom_cli_static_words a\n"
+	if !strings.Contains(buf.String(), want) {
+		t.Errorf("bash script does not contain %q", want)
+	}
+
+	if err := writeStaticScript(ioutil.Discard, "csh", programs); err == nil {
+		t.Error("no error for csh")
+	}
+}
@@ -0,0 +1,123 @@
+package factomcli
+
+import (
+	"encoding/json"
+	"sort"
+	"time"
+
+	"github.com/AdamSLevy/factom"
+	"github.com/posener/complete"
+)
+
+// txIDTimeFormat is the format of the timestamps in TXID descriptions.
+const txIDTimeFormat = "2006-01-02 15:04"
+
+// PredictTxID predicts the TXIDs of pending transactions and of the
+// transactions of the wallet, newest first.
+var PredictTxID = complete.PredictFunc(rpcPredictors.txID)
+
+func (p predictors) txID(complete.Args) []string {
+	var txs []txIDCache
+	if err := p.loadCached(cacheTxIDs, &txs); err != nil {
+		complete.Log("error: %v", err)
+		return nil
+	}
+	txids := make([]string, len(txs))
+	for i, tx := range txs {
+		desc := factom.FactoshiToFactoid(tx.Amount) + " FCT, "
+		if tx.Pending {
+			desc += "pending"
+		} else {
+			desc += tx.Timestamp.Local().Format(txIDTimeFormat)
+		}
+		txids[i] = describe(tx.TxID, desc)
+	}
+	return txids
+}
+
+// txIDCache is a transaction as stored in the completion cache. The amount
+// is the total of its inputs in factoshis.
+type txIDCache struct {
+	TxID      string    `json:"txid"`
+	Amount    uint64    `json:"amount"`
+	Timestamp time.Time `json:"timestamp"`
+	Pending   bool      `json:"pending"`
+}
+
+// fetchTxIDs fetches the pending transactions from factomd and the
+// transactions of the wallet from factom-walletd, newest first. If the
+// wallet can not list all of its transactions, those of each of its
+// addresses are listed instead.
+func fetchTxIDs(c Client) (interface{}, error) {
+	var txs []txIDCache
+	seen := make(map[string]bool)
+	pending, err := fetchPendingTransactions(c)
+	if err != nil {
+		complete.Log("error: %v", err)
+	}
+	for _, tx := range pending {
+		if !seen[tx.TxID] {
+			seen[tx.TxID] = true
+			txs = append(txs, tx)
+		}
+	}
+
+	wallet, err := c.ListTransactionsAll()
+	if err != nil {
+		complete.Log("error: %v", err)
+		wallet = nil
+		addresses, err := walletAddresses(c)
+		if err != nil {
+			return nil, err
+		}
+		for _, address := range append(addresses.FCT, addresses.EC...) {
+			addressTxs, err := c.ListTransactionsAddress(address)
+			if err != nil {
+				complete.Log("error: %v", err)
+				continue
+			}
+			wallet = append(wallet, addressTxs...)
+		}
+	}
+	sort.SliceStable(wallet, func(i, j int) bool {
+		return wallet[i].Timestamp.After(wallet[j].Timestamp)
+	})
+	for _, tx := range wallet {
+		if len(tx.TxID) == 0 || seen[tx.TxID] {
+			continue
+		}
+		seen[tx.TxID] = true
+		txs = append(txs, txIDCache{
+			TxID:      tx.TxID,
+			Amount:    tx.TotalInputs,
+			Timestamp: tx.Timestamp,
+		})
+	}
+	return txs, nil
+}
+
+// fetchPendingTransactions returns the transactions that are pending in
+// factomd.
+func fetchPendingTransactions(c Client) ([]txIDCache, error) {
+	result, err := c.GetPendingTransactions()
+	if err != nil {
+		return nil, err
+	}
+	var pending []struct {
+		TransactionID string `json:"transactionid"`
+		Inputs        []struct {
+			Amount uint64 `json:"amount"`
+		} `json:"inputs"`
+	}
+	if err := json.Unmarshal([]byte(result), &pending); err != nil {
+		return nil, err
+	}
+	txs := make([]txIDCache, len(pending))
+	for i, tx := range pending {
+		txs[i] = txIDCache{TxID: tx.TransactionID, Pending: true}
+		for _, input := range tx.Inputs {
+			txs[i].Amount += input.Amount
+		}
+	}
+	return txs, nil
+}
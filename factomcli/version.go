@@ -0,0 +1,203 @@
+package factomcli
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/posener/complete"
+)
+
+// envVersion overrides the detected version of factom-cli.
+const envVersion = "COMPLETE_FACTOM_CLI_VERSION"
+
+// versionTimeout is how long factom-cli may take to print its version.
+const versionTimeout = 2 * time.Second
+
+// versionRetry is how long a failure to detect the version of factom-cli is
+// remembered before it is detected again.
+const versionRetry = 10 * time.Minute
+
+// versionPrefix starts the line of the output of factom-cli properties that
+// holds the version of factom-cli.
+const versionPrefix = "CLI Version:"
+
+// factomCLIVersion returns the version of factom-cli set in envVersion, or
+// else that of the factom-cli on the PATH. The version is detected once by
+// running factom-cli properties and remembered until factom-cli changes. It
+// returns "" if the version is unknown.
+func factomCLIVersion() string {
+	if version := os.Getenv(envVersion); len(version) > 0 {
+		return version
+	}
+	if len(os.Getenv("COMP_LINE")) == 0 {
+		// Only completion needs the version.
+		return ""
+	}
+	path, err := exec.LookPath("factom-cli")
+	if err != nil {
+		complete.Log("error: %v", err)
+		return ""
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		complete.Log("error: %v", err)
+		return ""
+	}
+
+	cached := loadVersion()
+	if cached.Path == path && cached.ModTime.Equal(info.ModTime()) &&
+		(len(cached.Version) > 0 ||
+			time.Since(cached.Checked) < versionRetry) {
+		return cached.Version
+	}
+	detected := detectedVersion{
+		Path:    path,
+		ModTime: info.ModTime(),
+		Checked: time.Now(),
+		Version: runVersion(path),
+	}
+	saveVersion(detected)
+	return detected.Version
+}
+
+// detectedVersion is the version of the factom-cli binary at Path, as it was
+// when it was last modified at ModTime. Version is empty if it could not be
+// detected.
+type detectedVersion struct {
+	Path    string    `json:"path"`
+	ModTime time.Time `json:"modtime"`
+	Checked time.Time `json:"checked"`
+	Version string    `json:"version"`
+}
+
+func versionPath() string {
+	return filepath.Join(dataDir(), "version.json")
+}
+
+func loadVersion() detectedVersion {
+	var v detectedVersion
+	data, err := ioutil.ReadFile(versionPath())
+	if err != nil {
+		if !os.IsNotExist(err) {
+			complete.Log("error: %v", err)
+		}
+		return v
+	}
+	if err := json.Unmarshal(data, &v); err != nil {
+		complete.Log("error: %v", err)
+	}
+	return v
+}
+
+func saveVersion(v detectedVersion) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		complete.Log("error: %v", err)
+		return
+	}
+	path := versionPath()
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		complete.Log("error: %v", err)
+		return
+	}
+	if err := writeFileAtomic(path, data); err != nil {
+		complete.Log("error: %v", err)
+	}
+}
+
+// runVersion runs factom-cli properties with the connection flags from the
+// command line and returns the version of factom-cli that it prints.
+func runVersion(path string) string {
+	parseConnectionFlags()
+	ctx, cancel := context.WithTimeout(context.Background(), versionTimeout)
+	defer cancel()
+	args := append(append([]string{}, connArgs...), "properties")
+	out, err := exec.CommandContext(ctx, path, args...).Output()
+	if err != nil {
+		complete.Log("error: %v", err)
+	}
+	scanner := bufio.NewScanner(bytes.NewReader(out))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if strings.HasPrefix(line, versionPrefix) {
+			return strings.TrimSpace(line[len(versionPrefix):])
+		}
+	}
+	return ""
+}
+
+// selectVersion returns the version in versions that describes the given
+// version of a program: the most recent one that is not newer. The most
+// recent version is returned if version is unknown, and the oldest if version
+// is older than all of them.
+func selectVersion(versions []string, version string) string {
+	sorted := append([]string{}, versions...)
+	sort.Slice(sorted, func(i, j int) bool {
+		return compareVersions(sorted[i], sorted[j]) < 0
+	})
+	if len(sorted) == 0 {
+		return ""
+	}
+	if parseVersion(version) == nil {
+		return sorted[len(sorted)-1]
+	}
+	selected := sorted[0]
+	for _, v := range sorted {
+		if compareVersions(v, version) <= 0 {
+			selected = v
+		}
+	}
+	return selected
+}
+
+// compareVersions returns -1, 0 or 1 if version a is older than, equal to or
+// newer than version b.
+func compareVersions(a, b string) int {
+	va, vb := parseVersion(a), parseVersion(b)
+	for i := 0; i < len(va) || i < len(vb); i++ {
+		var na, nb int
+		if i < len(va) {
+			na = va[i]
+		}
+		if i < len(vb) {
+			nb = vb[i]
+		}
+		if na != nb {
+			if na < nb {
+				return -1
+			}
+			return 1
+		}
+	}
+	return 0
+}
+
+// parseVersion returns the numbers of a version such as v2.2.14 or
+// 2.2.14-rc1. It returns nil if version does not start with a number.
+func parseVersion(version string) []int {
+	version = strings.TrimPrefix(strings.TrimSpace(version), "v")
+	if i := strings.IndexFunc(version, func(r rune) bool {
+		return r != '.' && (r < '0' || r > '9')
+	}); i >= 0 {
+		version = version[:i]
+	}
+	var numbers []int
+	for _, field := range strings.Split(version, ".") {
+		n, err := strconv.Atoi(field)
+		if err != nil {
+			break
+		}
+		numbers = append(numbers, n)
+	}
+	return numbers
+}
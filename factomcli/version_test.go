@@ -0,0 +1,121 @@
+package factomcli
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSelectVersion(t *testing.T) {
+	versions := []string{"v2.2.14", "v2.2.0", "v2.2.15"}
+	tests := []struct {
+		version, want string
+	}{
+		{"", "v2.2.15"},
+		{"unknown", "v2.2.15"},
+		{"v2.1.9", "v2.2.0"},
+		{"v2.2.0", "v2.2.0"},
+		{"v2.2.9", "v2.2.0"},
+		{"2.2.14", "v2.2.14"},
+		{"v2.2.14-rc1", "v2.2.14"},
+		{"v2.2.15", "v2.2.15"},
+		{"v3.0.0", "v2.2.15"},
+	}
+	for _, test := range tests {
+		if got := selectVersion(versions, test.version); got != test.want {
+			t.Errorf("%q: got %q, want %q", test.version, got, test.want)
+		}
+	}
+}
+
+// TestVersionCommands checks that only the commands and flags of the
+// selected version of factom-cli are completed.
+func TestVersionCommands(t *testing.T) {
+	f := newFixture(t)
+	defer f.close()
+
+	tests := []struct {
+		version, line string
+		want          []string
+	}{
+		{"v2.2.0", "buy", []string{"buyexactec"}},
+		{"v2.2.14", "buy", []string{"buyec"}},
+		{"v2.2.0", "buyexactec ", []string{fakeFA1, fakeFA2}},
+		{"v2.2.0", "listtxs tmp -", nil},
+		{"v2.2.14", "listtxs tmp -", []string{"-N"}},
+		{"v2.2.14", "get heights -", nil},
+		{"v2.2.15", "get heights -", []string{"-D", "-L", "-B", "-E"}},
+	}
+	for _, test := range tests {
+		f.cli = newCLI(test.version, rpcClient{})
+		assertCandidates(t, test.version+" "+test.line,
+			f.complete("bash", test.line), test.want)
+	}
+}
+
+// TestFactomCLIVersion checks that the version of factom-cli is detected
+// once and again only after factom-cli changes.
+func TestFactomCLIVersion(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("runs a shell script as factom-cli")
+	}
+	f := newFixture(t)
+	defer f.close()
+	path := os.Getenv("PATH")
+	defer os.Setenv("PATH", path)
+	defer os.Setenv(envVersion, os.Getenv(envVersion))
+	os.Unsetenv(envVersion)
+
+	bin := filepath.Join(f.home, "bin")
+	if err := os.Mkdir(bin, 0755); err != nil {
+		t.Fatal(err)
+	}
+	os.Setenv("PATH", bin+string(os.PathListSeparator)+path)
+	runs := filepath.Join(f.home, "runs")
+	cli := filepath.Join(bin, "factom-cli")
+	writeCLI := func(version string) {
+		script := fmt.Sprintf("#!/bin/sh\necho \"$@\" >> %v\n"+
+			"echo 'CLI Version: %v'\necho 'Wallet Version: v0.0.1'\n",
+			runs, version)
+		if err := ioutil.WriteFile(cli, []byte(script), 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	os.Setenv("HOME", f.home)
+	os.Setenv("COMP_LINE", "factom-cli -w localhost:1234 ")
+	connConfigured, connArgs = false, nil
+	writeCLI("v2.2.0")
+	for i := 0; i < 2; i++ {
+		if got := factomCLIVersion(); got != "v2.2.0" {
+			t.Errorf("got %q, want %q", got, "v2.2.0")
+		}
+	}
+	data, _ := ioutil.ReadFile(runs)
+	if got := string(data); got != "-w localhost:1234 properties\n" {
+		t.Errorf("factom-cli ran with %q", got)
+	}
+
+	writeCLI("v2.2.14")
+	later := time.Now().Add(time.Minute)
+	if err := os.Chtimes(cli, later, later); err != nil {
+		t.Fatal(err)
+	}
+	if got := factomCLIVersion(); got != "v2.2.14" {
+		t.Errorf("got %q after an update, want %q", got, "v2.2.14")
+	}
+	data, _ = ioutil.ReadFile(runs)
+	if n := strings.Count(string(data), "\n"); n != 2 {
+		t.Errorf("factom-cli ran %v times, want 2", n)
+	}
+
+	os.Setenv(envVersion, "v2.2.15")
+	if got := factomCLIVersion(); got != "v2.2.15" {
+		t.Errorf("got %q, want the override %q", got, "v2.2.15")
+	}
+}
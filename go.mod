@@ -2,6 +2,7 @@ module github.com/AdamSLevy/complete-factom-cli
 
 require (
 	github.com/AdamSLevy/factom v0.0.0-20180830194820-f09dea5bd165
+	github.com/FactomProject/go-bip39 v0.0.0-20161217174232-d1007fb78d9a
 	github.com/hashicorp/errwrap v0.0.0-20180715044906-d6c0cd880357 // indirect
 	github.com/hashicorp/go-multierror v0.0.0-20180717150148-3d5d8f294aa0 // indirect
 	github.com/posener/complete v1.1.2